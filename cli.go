@@ -12,18 +12,49 @@ import (
 // It returns exit code.
 func Run(args []string) int {
 
+	// The sandbox re-executes goyuki to set up the root filesystem.
+	if len(args) > 0 && args[0] == command.SandboxCommand {
+		return command.SandboxInit(args[1:])
+	}
+
+	// The defaults are used if the config is broken so that the other commands still work.
+	config, err := command.LoadConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, command.Message(command.DetectLocale(), "config.useDefault", err))
+		conf := command.DefaultConfig
+		config = &conf
+	}
+
+	// -color overrides color in the config
+	mode, args, err := command.ColorFlag(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if mode == "" {
+		mode = config.Color
+	}
+	color := command.UseColor(mode, os.Stdout)
+	command.SetColor(color)
+
 	// Meta-option for executables.
 	// It defines output color and its stdout/stderr stream.
-	meta := &command.Meta{
-		UI: &cli.ColoredUi{
+	var ui cli.Ui = &cli.BasicUi{
+		Writer:      os.Stdout,
+		ErrorWriter: os.Stderr,
+		Reader:      os.Stdin,
+	}
+	if color {
+		ui = &cli.ColoredUi{
 			InfoColor:  cli.UiColorBlue,
 			ErrorColor: cli.UiColorRed,
-			Ui: &cli.BasicUi{
-				Writer:      os.Stdout,
-				ErrorWriter: os.Stderr,
-				Reader:      os.Stdin,
-			},
-		}}
+			Ui:         ui,
+		}
+	}
+	meta := &command.Meta{
+		UI:     ui,
+		Config: config,
+	}
 
 	return RunCustom(args, Commands(meta))
 }
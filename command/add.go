@@ -0,0 +1,204 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// AddCommand is a Command that add a custom test case
+type AddCommand struct {
+	Meta
+}
+
+// Run add a custom test case
+func (c *AddCommand) Run(args []string) int {
+	var (
+		inputFlag   string
+		outputFlag  string
+		refFlag     string
+		langFlag    string
+		nameFlag    string
+		editorFlag  bool
+		verboseFlag bool
+	)
+
+	flags := c.Meta.NewFlagSet("add", c.Help())
+	flags.StringVar(&inputFlag, "i", "", "Specify input file")
+	flags.StringVar(&inputFlag, "input", "", "Specify input file")
+	flags.StringVar(&outputFlag, "o", "", "Specify output file")
+	flags.StringVar(&outputFlag, "output", "", "Specify output file")
+	flags.StringVar(&refFlag, "r", "", "Specify reference solution")
+	flags.StringVar(&refFlag, "ref", "", "Specify reference solution")
+	flags.StringVar(&langFlag, "l", "", "Specify Language of reference solution")
+	flags.StringVar(&langFlag, "language", "", "Specify Language of reference solution")
+	flags.StringVar(&nameFlag, "n", "", "Specify test case name")
+	flags.StringVar(&nameFlag, "name", "", "Specify test case name")
+	flags.BoolVar(&editorFlag, "e", false, "edit with $EDITOR")
+	flags.BoolVar(&editorFlag, "editor", false, "edit with $EDITOR")
+	flags.BoolVar(&verboseFlag, "vb", false, "increase amount of output")
+	flags.BoolVar(&verboseFlag, "verbose", false, "increase amount of output")
+
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(c.msg("error.invalidOption", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+	args = flags.Args()
+
+	if len(args) < 1 {
+		c.UI.Error(c.msg("error.invalidArguments", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+
+	dir, err := ProblemDir(c.root(), args[0])
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+	args[0] = dir
+
+	if _, err := os.Stat(args[0]); err != nil {
+		c.UI.Error(c.msg("error.noDirectory"))
+		return ExitCodeFailed
+	}
+
+	if outputFlag != "" && refFlag != "" {
+		c.UI.Error(c.msg("add.exclusive"))
+		return ExitCodeFailed
+	}
+
+	if outputFlag == "" && refFlag == "" && !editorFlag {
+		c.UI.Error(c.msg("add.noOutput"))
+		return ExitCodeFailed
+	}
+
+	var lang []string
+	if refFlag != "" {
+		var err error
+		lang, err = sourceLang(refFlag, langFlag)
+		if err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+	}
+
+	var input []byte
+	switch {
+	case inputFlag != "":
+		input, err = ioutil.ReadFile(inputFlag)
+	case editorFlag:
+		input, err = editText("input", nil)
+	default:
+		input, err = ioutil.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		c.UI.Error(c.msg("add.readInput", err))
+		return ExitCodeFailed
+	}
+
+	var w, e io.Writer
+	if verboseFlag {
+		w, e = os.Stdout, os.Stderr
+	}
+
+	var output []byte
+	switch {
+	case outputFlag != "":
+		output, err = ioutil.ReadFile(outputFlag)
+	case refFlag != "":
+		output, err = refOutput(args[0], refFlag, lang, input, w, e)
+	default:
+		output, err = editText("output", nil)
+	}
+	if err != nil {
+		c.UI.Error(c.msg("add.makeOutput", err))
+		return ExitCodeFailed
+	}
+
+	if nameFlag == "" {
+		nameFlag = nextCustomName(args[0])
+	}
+
+	name, err := saveTestCase(args[0], CustomPrefix+nameFlag, input, output)
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+	c.UI.Info(c.msg("add.added", name))
+	return ExitCodeOK
+}
+
+// Synopsis is a one-line, short synopsis of the command.
+func (c *AddCommand) Synopsis() string {
+	return c.msg("add.synopsis")
+}
+
+// Help is a long-form help text
+func (c *AddCommand) Help() string {
+	return strings.TrimSpace(c.msg("add.help"))
+}
+
+// refOutput compiles the reference solution and returns its output for input
+func refOutput(dir, source string, lang []string, input []byte, w, e io.Writer) ([]byte, error) {
+	info, err := ReadInfo(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	code, _, clearFunc, err := NewCode(source, lang, info, nil, w, e)
+	if err != nil {
+		return nil, err
+	}
+	defer clearFunc()
+
+	return code.Output(bytes.NewReader(input), e)
+}
+
+// editText opens $EDITOR with text and returns the edited text
+func editText(name string, text []byte) ([]byte, error) {
+	f, err := ioutil.TempFile("", "goyuki-"+name+"-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(text); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+
+	com := append(strings.Fields(editor), f.Name())
+	cmd := exec.Command(com[0], com[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("editor error: %v", err)
+	}
+	return ioutil.ReadFile(f.Name())
+}
+
+// nextCustomName returns the first unused serial number of custom test case
+func nextCustomName(dir string) string {
+	cases, _, _ := TestCases(dir)
+	used := map[string]bool{}
+	for _, tc := range cases {
+		used[caseName(tc.Name)] = true
+	}
+
+	for n := 1; ; n++ {
+		if !used[fmt.Sprintf("%s%d", CustomPrefix, n)] {
+			return fmt.Sprint(n)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestAddCommand_implement(t *testing.T) {
+	var _ cli.Command = &AddCommand{}
+}
+
+func TestAddCommandFlag(t *testing.T) {
+	testCases := []struct {
+		args   []string
+		code   int
+		result string
+	}{
+		{args: []string{"-foo", "lang", "-hoge"}, code: ExitCodeFailed, result: "不正なオプション"},
+		{args: []string{}, code: ExitCodeFailed, result: "不正な引数"},
+		{args: []string{"-o", "out.txt", "-r", "ref.py", "testdata/337"}, code: ExitCodeFailed, result: "同時に指定できません"},
+		{args: []string{"-i", "in.txt", "testdata/337"}, code: ExitCodeFailed, result: "期待する出力が必要です"},
+		{args: []string{"-i", "in.txt", "-r", "ref.hoge", "testdata/337"}, code: ExitCodeFailed, result: "不正な言語"},
+	}
+
+	for _, testCase := range testCases {
+		ui := new(cli.MockUi)
+		c := &AddCommand{
+			Meta: Meta{
+				UI: ui,
+			},
+		}
+
+		code := c.Run(testCase.args)
+		errs := ui.ErrorWriter.String()
+
+		if code != testCase.code || !strings.Contains(errs, testCase.result) {
+			t.Errorf("bad status code = %v; want %v\nError message = %s; want %s", code, testCase.code, errs, testCase.result)
+		}
+	}
+}
+
+func TestAddCommandFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	in, out := filepath.Join(dir, "in"), filepath.Join(dir, "out")
+	if err := ioutil.WriteFile(in, []byte("1 2\n"), FPerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(out, []byte("3\n"), FPerm); err != nil {
+		t.Fatal(err)
+	}
+
+	for n := 1; n <= 2; n++ {
+		ui := new(cli.MockUi)
+		c := &AddCommand{
+			Meta: Meta{
+				UI: ui,
+			},
+		}
+
+		if code := c.Run([]string{"-i", in, "-o", out, dir}); code != ExitCodeOK {
+			t.Fatalf("bad status code = %v; want %v\nError message = %s", code, ExitCodeOK, ui.ErrorWriter.String())
+		}
+	}
+
+	cases, warnings, err := TestCases(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) != 2 || len(warnings) != 0 {
+		t.Fatalf("cases = %d, warnings = %v; want 2 cases", len(cases), warnings)
+	}
+
+	for i, name := range []string{"custom_1.txt", "custom_2.txt"} {
+		if cases[i].Name != name || !cases[i].Custom {
+			t.Errorf("case %d = %+v; want custom case %s", i, cases[i], name)
+		}
+	}
+}
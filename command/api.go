@@ -0,0 +1,289 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// APIURL is yukicoder API url
+const APIURL = "https://yukicoder.me/api/v1"
+
+// APIClient is a client of yukicoder API
+type APIClient struct {
+	URL   string
+	Token string
+
+	// HTTP sends the requests (DefaultHTTPClient if nil)
+	HTTP *HTTPClient
+}
+
+// APIProblem is a problem returned by yukicoder API
+type APIProblem struct {
+	No          int
+	ProblemID   int `json:"ProblemId"`
+	Title       string
+	AuthorID    int `json:"AuthorId"`
+	TesterID    int `json:"TesterId"`
+	Level       float64
+	ProblemType int
+	Tags        string
+	Date        string
+}
+
+// APIContest is a contest returned by yukicoder API
+type APIContest struct {
+	ID            int `json:"Id"`
+	Name          string
+	Date          string
+	EndDate       string
+	ProblemIDList []int `json:"ProblemIdList"`
+}
+
+// APISubmission is a submission returned by yukicoder API
+type APISubmission struct {
+	ID        int `json:"Id"`
+	ProblemID int `json:"ProblemId"`
+	Language  string
+	Result    string
+	TestCases []*APITestCaseResult `json:"TestCaseResults"`
+}
+
+// APITestCaseResult is a judge result of a test case
+type APITestCaseResult struct {
+	Name   string
+	Result string
+	Time   int
+	Memory int
+}
+
+// Judging reports whether the submission is waiting for judge or being judged
+func (s *APISubmission) Judging() bool {
+	return s.Result == "" || s.Result == "WJ" || s.Result == "Judge"
+}
+
+// errNotFound is returned by request when API responds 404
+var errNotFound = errors.New("not found")
+
+// NewAPIClient returns a client of yukicoder API.
+// token is used as the bearer token if it is not empty.
+func NewAPIClient(token string) *APIClient {
+	return &APIClient{URL: APIURL, Token: token}
+}
+
+// Problem gets the problem of the number
+func (a *APIClient) Problem(num int) (*APIProblem, error) {
+	p := &APIProblem{}
+	if err := a.getJSON(p, "problems", "no", fmt.Sprint(num)); err != nil {
+		return nil, problemError(err)
+	}
+	return p, nil
+}
+
+// Problems gets all problems
+func (a *APIClient) Problems() ([]*APIProblem, error) {
+	var ps []*APIProblem
+	if err := a.getJSON(&ps, "problems"); err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
+// ProblemByID gets the problem of the problem id
+func (a *APIClient) ProblemByID(id int) (*APIProblem, error) {
+	p := &APIProblem{}
+	if err := a.getJSON(p, "problems", fmt.Sprint(id)); err != nil {
+		return nil, problemError(err)
+	}
+	return p, nil
+}
+
+// Contest gets the contest of the contest id
+func (a *APIClient) Contest(id int) (*APIContest, error) {
+	contest := &APIContest{}
+	if err := a.getJSON(contest, "contest", "id", fmt.Sprint(id)); err != nil {
+		if err == errNotFound {
+			return nil, newMsgError("api.contestNotFound")
+		}
+		return nil, err
+	}
+	return contest, nil
+}
+
+// TestCaseFiles gets the file names of test cases.
+// kind is "in" or "out".
+func (a *APIClient) TestCaseFiles(num int, kind string) ([]string, error) {
+	var files []string
+	if err := a.getJSON(&files, "problems", "no", fmt.Sprint(num), "file", kind); err != nil {
+		return nil, problemError(err)
+	}
+	return files, nil
+}
+
+// TestCaseFile gets the content of the test case file
+func (a *APIClient) TestCaseFile(num int, kind, name string) ([]byte, error) {
+	res, err := a.request("problems", "no", fmt.Sprint(num), "file", kind, name)
+	if err != nil {
+		return nil, problemError(err)
+	}
+	defer res.Body.Close()
+
+	return ioutil.ReadAll(res.Body)
+}
+
+// TestCases gets all test case files keyed by the path relative to the problem directory
+func (a *APIClient) TestCases(num int) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	for kind, dir := range map[string]string{"in": InputDir, "out": OutputDir} {
+		names, err := a.TestCaseFiles(num, kind)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range names {
+			b, err := a.TestCaseFile(num, kind, name)
+			if err != nil {
+				return nil, err
+			}
+			files[path.Join(dir, path.Base(name))] = b
+		}
+	}
+	return files, nil
+}
+
+// Submit submits the source and returns the submission id.
+// lang is the language id of yukicoder.
+func (a *APIClient) Submit(num int, lang string, source []byte) (int, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	if err := mw.WriteField("lang", lang); err != nil {
+		return 0, err
+	}
+	fw, err := mw.CreateFormFile("file", "source")
+	if err != nil {
+		return 0, err
+	}
+	if _, err := fw.Write(source); err != nil {
+		return 0, err
+	}
+	if err := mw.Close(); err != nil {
+		return 0, err
+	}
+
+	res, err := a.do("POST", &body, mw.FormDataContentType(), "problems", "no", fmt.Sprint(num), "submit")
+	if err != nil {
+		return 0, problemError(err)
+	}
+	defer res.Body.Close()
+
+	var ret struct {
+		SubmissionID int `json:"SubmissionId"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&ret); err != nil {
+		return 0, fmt.Errorf("api response parse error: %v", err)
+	}
+	return ret.SubmissionID, nil
+}
+
+// Submission gets the submission of the id
+func (a *APIClient) Submission(id int) (*APISubmission, error) {
+	s := &APISubmission{}
+	if err := a.getJSON(s, "submissions", fmt.Sprint(id)); err != nil {
+		if err == errNotFound {
+			return nil, newMsgError("api.submissionNotFound")
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+func (a *APIClient) getJSON(v interface{}, elem ...string) error {
+	res, err := a.request(elem...)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		return fmt.Errorf("api response parse error: %v", err)
+	}
+	return nil
+}
+
+func (a *APIClient) request(elem ...string) (*http.Response, error) {
+	return a.do("GET", nil, "", elem...)
+}
+
+func (a *APIClient) do(method string, body io.Reader, contentType string, elem ...string) (*http.Response, error) {
+	for n, e := range elem {
+		elem[n] = url.PathEscape(e)
+	}
+
+	req, err := http.NewRequest(method, strings.TrimSuffix(a.URL, "/")+"/"+strings.Join(elem, "/"), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if a.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+a.Token)
+	}
+
+	client := a.HTTP
+	if client == nil {
+		client = DefaultHTTPClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed api request: %v", err)
+	}
+
+	switch res.StatusCode {
+	case 200:
+		return res, nil
+	case 401, 403:
+		res.Body.Close()
+		return nil, newMsgError("api.unauthorized")
+	case 404:
+		res.Body.Close()
+		return nil, errNotFound
+	}
+	res.Body.Close()
+	return nil, fmt.Errorf("api error: %s", res.Status)
+}
+
+func problemError(err error) error {
+	if err == errNotFound {
+		return newMsgError("error.problemNotFound")
+	}
+	return err
+}
+
+// Info converts the problem into Info.
+// Time, Mem and JudgeType are not provided by API.
+func (p *APIProblem) Info() *Info {
+	var tags []string
+	for _, tag := range strings.Split(p.Tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return &Info{
+		No:     fmt.Sprint(p.ProblemID),
+		Number: p.No,
+		Name:   p.Title,
+		Level:  int(p.Level),
+		Tags:   tags,
+	}
+}
@@ -0,0 +1,122 @@
+package command
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func newAPITestServer(token string) *httptest.Server {
+	files := map[string]map[string]string{
+		"in":  {"1.txt": "1 2\n", "2.txt": "3 4\n"},
+		"out": {"1.txt": "3\n", "2.txt": "7\n"},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/problems/no/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"No":1,"ProblemId":17,"Title":"道のショートカット","Level":3.0,"Tags":"グラフ, 最短経路"}`)
+	})
+	for kind, fs := range files {
+		kind, fs := kind, fs
+		mux.HandleFunc("/problems/no/1/file/"+kind, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `["1.txt","2.txt"]`)
+		})
+		for name, content := range fs {
+			content := content
+			mux.HandleFunc("/problems/no/1/file/"+kind+"/"+name, func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, content)
+			})
+		}
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token != "" && r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+}
+
+func TestAPIClientProblem(t *testing.T) {
+	ts := newAPITestServer("")
+	defer ts.Close()
+
+	api := &APIClient{URL: ts.URL}
+	p, err := api.Problem(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Info{No: "17", Number: 1, Name: "道のショートカット", Level: 3, Tags: []string{"グラフ", "最短経路"}}
+	if i := p.Info(); !reflect.DeepEqual(i, want) {
+		t.Errorf("Info() = %+v; want %+v", i, want)
+	}
+
+	if _, err := api.Problem(99999); err == nil || err.Error() != "問題が存在しません" {
+		t.Errorf("Problem(99999) error = %v; want 問題が存在しません", err)
+	}
+}
+
+func TestAPIClientTestCases(t *testing.T) {
+	ts := newAPITestServer("secret")
+	defer ts.Close()
+
+	api := &APIClient{URL: ts.URL}
+	if _, err := api.TestCases(1); err == nil {
+		t.Error("TestCases without token = nil; want authorization error")
+	}
+
+	api.Token = "secret"
+	files, err := api.TestCases(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][]byte{
+		"test_in/1.txt":  []byte("1 2\n"),
+		"test_in/2.txt":  []byte("3 4\n"),
+		"test_out/1.txt": []byte("3\n"),
+		"test_out/2.txt": []byte("7\n"),
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("TestCases() = %q; want %q", files, want)
+	}
+}
+
+func TestAPIClientContest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/contest/id/100":
+			fmt.Fprint(w, `{"Id":100,"Name":"yukicoder contest 100","ProblemIdList":[17,18]}`)
+		case "/problems/18":
+			fmt.Fprint(w, `{"No":2,"ProblemId":18,"Title":"素因数ゲーム"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	api := &APIClient{URL: ts.URL}
+	contest, err := api.Contest(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contest.Name != "yukicoder contest 100" || !reflect.DeepEqual(contest.ProblemIDList, []int{17, 18}) {
+		t.Errorf("Contest(100) = %+v", contest)
+	}
+
+	p, err := api.ProblemByID(18)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.No != 2 {
+		t.Errorf("ProblemByID(18).No = %d; want 2", p.No)
+	}
+
+	if _, err := api.Contest(1); err == nil || err.Error() != "コンテストが存在しません" {
+		t.Errorf("Contest(1) error = %v; want コンテストが存在しません", err)
+	}
+}
@@ -0,0 +1,108 @@
+package command
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Limits of the test case archive
+var (
+	MaxArchiveEntries        = 10000
+	MaxArchiveFileSize int64 = 256 << 20
+	MaxArchiveSize     int64 = 1 << 30
+)
+
+// unzipFiles extracts the test case archive.
+// Entries escaping the problem directory, symlinks and archives exceeding the limits are rejected.
+func unzipFiles(buf []byte) (map[string][]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(zr.File) > MaxArchiveEntries {
+		return nil, fmt.Errorf("testcase archive has too many entries: %d (max %d)", len(zr.File), MaxArchiveEntries)
+	}
+
+	var total int64
+	files := map[string][]byte{}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		name, err := cleanName(f.Name)
+		if err != nil {
+			return nil, err
+		}
+		if f.Mode()&os.ModeType != 0 {
+			return nil, fmt.Errorf("unsafe testcase archive: %s is not a regular file", f.Name)
+		}
+
+		// the size in the header can't be trusted
+		b, err := func() ([]byte, error) {
+			rc, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer rc.Close()
+			return ioutil.ReadAll(io.LimitReader(rc, MaxArchiveFileSize+1))
+		}()
+		if err != nil {
+			return nil, err
+		}
+
+		if int64(len(b)) > MaxArchiveFileSize {
+			return nil, fmt.Errorf("testcase archive too large: %s exceeds %d bytes", f.Name, MaxArchiveFileSize)
+		}
+		if total += int64(len(b)); total > MaxArchiveSize {
+			return nil, fmt.Errorf("testcase archive too large: exceeds %d bytes", MaxArchiveSize)
+		}
+		files[name] = b
+	}
+	return files, nil
+}
+
+// cleanName returns the slash-separated relative path of the file name.
+// It fails if the name escapes the directory.
+func cleanName(name string) (string, error) {
+	slashed := strings.Replace(name, "\\", "/", -1)
+	clean := path.Clean(slashed)
+	if clean == "." || path.IsAbs(clean) || filepath.VolumeName(name) != "" ||
+		clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("unsafe path in testcase archive: %s", name)
+	}
+	return clean, nil
+}
+
+// safeJoin joins the file name to the directory.
+// It fails if the path escapes the directory or passes through a symlink.
+func safeJoin(baseDir, name string) (string, error) {
+	clean, err := cleanName(name)
+	if err != nil {
+		return "", err
+	}
+
+	p := baseDir
+	for _, elem := range strings.Split(clean, "/") {
+		p = filepath.Join(p, elem)
+		fi, err := os.Lstat(p)
+		if os.IsNotExist(err) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if fi.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("refuse to write through symlink: %s", p)
+		}
+	}
+	return filepath.Join(baseDir, filepath.FromSlash(clean)), nil
+}
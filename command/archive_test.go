@@ -0,0 +1,122 @@
+package command
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type zipEntry struct {
+	name string
+	body string
+	mode os.FileMode
+}
+
+func testZip(t *testing.T, entries []zipEntry) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		h := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		if e.mode != 0 {
+			h.SetMode(e.mode)
+		}
+		w, err := zw.CreateHeader(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(e.body))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestUnzipFiles(t *testing.T) {
+	files, err := unzipFiles(testZip(t, []zipEntry{
+		{name: "test_in/"},
+		{name: "test_in/1.txt", body: "1\n"},
+		{name: "test_out/sub/dir/1.txt", body: "2\n"},
+		{name: "test_out\\2.txt", body: "3\n"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"test_in/1.txt":          "1\n",
+		"test_out/sub/dir/1.txt": "2\n",
+		"test_out/2.txt":         "3\n",
+	}
+	if len(files) != len(expected) {
+		t.Errorf("unzipFiles = %v", files)
+	}
+	for name, body := range expected {
+		if string(files[name]) != body {
+			t.Errorf("%s = %q; want %q", name, files[name], body)
+		}
+	}
+}
+
+func TestUnzipFilesUnsafe(t *testing.T) {
+	defer func(entries int, size int64) {
+		MaxArchiveEntries, MaxArchiveFileSize = entries, size
+	}(MaxArchiveEntries, MaxArchiveFileSize)
+	MaxArchiveEntries, MaxArchiveFileSize = 3, 100
+
+	testCases := []struct {
+		entries []zipEntry
+		result  string
+	}{
+		{entries: []zipEntry{{name: "../../.bashrc"}}, result: "unsafe path"},
+		{entries: []zipEntry{{name: "test_in/../../x"}}, result: "unsafe path"},
+		{entries: []zipEntry{{name: "/etc/passwd"}}, result: "unsafe path"},
+		{entries: []zipEntry{{name: "..\\x"}}, result: "unsafe path"},
+		{entries: []zipEntry{{name: "test_in/link", body: "/etc/passwd", mode: os.ModeSymlink | 0777}}, result: "not a regular file"},
+		{entries: []zipEntry{{name: "a"}, {name: "b"}, {name: "c"}, {name: "d"}}, result: "too many entries"},
+		{entries: []zipEntry{{name: "a", body: strings.Repeat("0", 101)}}, result: "too large"},
+	}
+
+	for _, testCase := range testCases {
+		_, err := unzipFiles(testZip(t, testCase.entries))
+		if err == nil || !strings.Contains(err.Error(), testCase.result) {
+			t.Errorf("unzipFiles(%s) error = %v; want %s", testCase.entries[0].name, err, testCase.result)
+		}
+	}
+}
+
+func TestWriteFilesSymlink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	outside := filepath.Join(dir, "outside")
+	problem := filepath.Join(dir, "1")
+	if err := os.MkdirAll(outside, DPerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(problem, DPerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(problem, "test_in")); err != nil {
+		t.Fatal(err)
+	}
+
+	err = writeFiles(problem, map[string][]byte{"test_in/1.txt": []byte("1\n")})
+	if err == nil || !strings.Contains(err.Error(), "symlink") {
+		t.Errorf("writeFiles error = %v; want symlink error", err)
+	}
+	if _, err := os.Stat(filepath.Join(outside, "1.txt")); err == nil {
+		t.Errorf("file is written through symlink")
+	}
+
+	if err := writeFiles(problem, map[string][]byte{"../x": []byte("1\n")}); err == nil {
+		t.Errorf("writeFiles should reject path traversal")
+	}
+}
@@ -0,0 +1,192 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// AtCoderURL is AtCoder contest url
+const AtCoderURL = "https://atcoder.jp/contests"
+
+// AtCoder is the Provider of AtCoder.
+// Only the sample cases in the problem statement are available.
+type AtCoder struct {
+	// HTTP sends the requests to the site (DefaultHTTPClient if nil)
+	HTTP *HTTPClient
+
+	mu    sync.Mutex
+	pages map[string][]byte
+}
+
+var (
+	atcoderID  = regexp.MustCompile(`^[a-z]+\d+_[a-z0-9]+$`)
+	atcoderURL = regexp.MustCompile(`^(?:https?://)?atcoder\.jp/contests/[^/]+/tasks/([a-z0-9_]+)/?$`)
+	atcoderTL  = regexp.MustCompile(`([\d.]+)\s*sec`)
+	atcoderML  = regexp.MustCompile(`(\d+)\s*MB`)
+)
+
+// Name returns the site name
+func (a *AtCoder) Name() string {
+	return "atcoder"
+}
+
+// Parse accepts a task id (abc123_a) or a task url
+func (a *AtCoder) Parse(spec string) (string, bool, error) {
+	if atcoderID.MatchString(spec) {
+		return spec, true, nil
+	}
+
+	if m := atcoderURL.FindStringSubmatch(spec); m != nil {
+		return m[1], true, nil
+	}
+	return "", false, nil
+}
+
+// Info gets the problem infomation from the task page
+func (a *AtCoder) Info(id string) (*Info, error) {
+	client := a.HTTP
+	if client == nil {
+		client = DefaultHTTPClient
+	}
+
+	res, err := client.Get(a.URL(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed problem request: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return nil, newMsgError("error.problemNotFound")
+	}
+
+	page, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	i, err := parseAtCoder(bytes.NewReader(page))
+	if err != nil {
+		return nil, err
+	}
+	i.No, i.Contest = id, id[:strings.LastIndex(id, "_")]
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.pages == nil {
+		a.pages = map[string][]byte{}
+	}
+	a.pages[id] = page
+	return i, nil
+}
+
+// TestCases gets the sample cases from the task page
+func (a *AtCoder) TestCases(id string, i *Info) (map[string][]byte, error) {
+	a.mu.Lock()
+	page := a.pages[id]
+	a.mu.Unlock()
+
+	samples, err := parseAtCoderSamples(bytes.NewReader(page))
+	if err != nil {
+		return nil, err
+	}
+	return sampleFiles(samples), nil
+}
+
+// JudgeCode is not available on AtCoder
+func (a *AtCoder) JudgeCode(id string, i *Info) ([]byte, error) {
+	return nil, fmt.Errorf("judge code is not available on AtCoder")
+}
+
+// Statement returns the task page downloaded by Info
+func (a *AtCoder) Statement(id string) []byte {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.pages[id]
+}
+
+// Forget drops the task page downloaded by Info
+func (a *AtCoder) Forget(id string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.pages, id)
+}
+
+// URL returns the task page url
+func (a *AtCoder) URL(id string) string {
+	contest := id[:strings.LastIndex(id, "_")]
+	return strings.Join([]string{AtCoderURL, contest, "tasks", id}, "/")
+}
+
+// SamplesOnly reports whether TestCases returns the sample cases only
+func (a *AtCoder) SamplesOnly() bool {
+	return true
+}
+
+func parseAtCoder(r io.Reader) (*Info, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("problem parse error: %v", err)
+	}
+
+	i := &Info{
+		Name: strings.TrimSpace(doc.Find("span.h2").First().Contents().First().Text()),
+		Time: DefaultTime,
+		Mem:  DefaultMem,
+	}
+
+	limit := doc.Find("#main-container p").First().Text()
+	if m := atcoderTL.FindStringSubmatch(limit); m != nil {
+		if t, err := strconv.ParseFloat(m[1], 64); err == nil {
+			i.Time = int(math.Ceil(t))
+		}
+	}
+	if m := atcoderML.FindStringSubmatch(limit); m != nil {
+		i.Mem, _ = strconv.Atoi(m[1])
+	}
+	return i, nil
+}
+
+func parseAtCoderSamples(r io.Reader) ([]*Sample, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("sample parse error: %v", err)
+	}
+
+	statement := doc.Find("#task-statement")
+	if ja := statement.Find("span.lang-ja"); ja.Size() > 0 {
+		statement = ja
+	}
+
+	var (
+		inputs, outputs [][]byte
+	)
+	statement.Find("section").Each(func(n int, e *goquery.Selection) {
+		title := e.Find("h3").First().Text()
+		pre := e.Find("pre").First()
+		switch {
+		case strings.HasPrefix(title, "入力例"), strings.HasPrefix(title, "Sample Input"):
+			inputs = append(inputs, sampleText(pre.Text()))
+		case strings.HasPrefix(title, "出力例"), strings.HasPrefix(title, "Sample Output"):
+			outputs = append(outputs, sampleText(pre.Text()))
+		}
+	})
+
+	if len(inputs) == 0 || len(inputs) != len(outputs) {
+		return nil, fmt.Errorf("sample cases not found")
+	}
+
+	samples := make([]*Sample, len(inputs))
+	for n := range inputs {
+		samples[n] = &Sample{In: inputs[n], Out: outputs[n]}
+	}
+	return samples, nil
+}
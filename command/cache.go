@@ -0,0 +1,134 @@
+package command
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// StatementFile is the problem statement page in the cache
+const StatementFile = "statement.html"
+
+// Cache is the local copy of the downloaded problems.
+// Each problem is stored in Dir/site/id with the same layout as the problem directory.
+type Cache struct {
+	Dir string
+}
+
+// Statementer is implemented by the providers which keep the problem page downloaded by Info
+type Statementer interface {
+	// Statement returns the problem page
+	Statement(id string) []byte
+	// URL returns the url of the problem page
+	URL(id string) string
+}
+
+// CacheDir returns goyuki cache directory ($XDG_CACHE_HOME/goyuki or ~/.cache/goyuki)
+func CacheDir() (string, error) {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "goyuki"), nil
+	}
+
+	home := os.Getenv("HOME")
+	if home == "" {
+		return "", fmt.Errorf("$HOME not set")
+	}
+	return filepath.Join(home, ".cache", "goyuki"), nil
+}
+
+// NewCache returns the cache in CacheDir
+func NewCache() (*Cache, error) {
+	dir, err := CacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{Dir: dir}, nil
+}
+
+// Path returns the cache directory of the problem
+func (c *Cache) Path(site, id string) string {
+	return filepath.Join(c.Dir, site, id)
+}
+
+// Exists reports whether the problem is cached
+func (c *Cache) Exists(site, id string) bool {
+	_, err := os.Stat(filepath.Join(c.Path(site, id), InfoFile))
+	return err == nil
+}
+
+// Store replaces the cached problem with files and the statement page.
+// statement is not stored if it is nil.
+func (c *Cache) Store(site, id string, files map[string][]byte, statement []byte) error {
+	if err := os.MkdirAll(filepath.Join(c.Dir, site), DPerm); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempDir(filepath.Join(c.Dir, site), "."+id)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := writeFiles(tmp, files); err != nil {
+		return err
+	}
+	if statement != nil {
+		if err := ioutil.WriteFile(filepath.Join(tmp, StatementFile), statement, FPerm); err != nil {
+			return err
+		}
+	}
+	if err := os.Chmod(tmp, DPerm); err != nil {
+		return err
+	}
+
+	p := c.Path(site, id)
+	if err := os.RemoveAll(p); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p)
+}
+
+// Load returns the cached problem files
+func (c *Cache) Load(site, id string) (*Info, map[string][]byte, error) {
+	if !c.Exists(site, id) {
+		return nil, nil, newMsgError("cache.notCached", site, id)
+	}
+
+	dir := c.Path(site, id)
+	files := map[string][]byte{}
+	err := filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return err
+		}
+
+		name, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if name == StatementFile {
+			return nil
+		}
+
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(name)] = b
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	i, err := ReadInfo(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	return i, files, nil
+}
+
+// Statement returns the cached problem statement page
+func (c *Cache) Statement(site, id string) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(c.Path(site, id), StatementFile))
+}
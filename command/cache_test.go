@@ -0,0 +1,160 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache := &Cache{Dir: dir}
+	if cache.Exists("yukicoder", "1") {
+		t.Fatalf("empty cache has problem")
+	}
+	if _, _, err := cache.Load("yukicoder", "1"); err == nil {
+		t.Errorf("Load should fail for uncached problem")
+	}
+
+	files := map[string][]byte{
+		InfoFile:              []byte(`{"No":"1","Name":"hoge","Time":2,"Mem":256}`),
+		"test_in/1.txt":       []byte("1\n"),
+		"test_out/1.txt":      []byte("2\n"),
+		ReactiveCode + ".cpp": []byte("int main(){}"),
+		"test_in/sub/2.txt":   []byte("3\n"),
+	}
+	if err := cache.Store("yukicoder", "1", files, []byte("<html></html>")); err != nil {
+		t.Fatal(err)
+	}
+
+	i, loaded, err := cache.Load("yukicoder", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i.Name != "hoge" || !reflect.DeepEqual(loaded, files) {
+		t.Errorf("Load = %+v, %v; want %v", i, loaded, files)
+	}
+	if b, err := cache.Statement("yukicoder", "1"); err != nil || string(b) != "<html></html>" {
+		t.Errorf("Statement = %s, %v", b, err)
+	}
+
+	delete(files, "test_in/1.txt")
+	if err := cache.Store("yukicoder", "1", files, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, loaded, _ := cache.Load("yukicoder", "1"); !reflect.DeepEqual(loaded, files) {
+		t.Errorf("Store should replace the cached problem: %v", loaded)
+	}
+
+	fis, _ := ioutil.ReadDir(filepath.Join(dir, "yukicoder"))
+	if len(fis) != 1 {
+		t.Errorf("temporary directory is left in cache: %d entries", len(fis))
+	}
+}
+
+func TestGetCommandOffline(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cacheDir)
+	_, clearDirs, err := tmpUserDirs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clearDirs()
+
+	workDir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(workDir)
+
+	cache := &Cache{Dir: cacheDir}
+	files := map[string][]byte{
+		InfoFile:         []byte(`{"No":"1","Name":"hoge","Time":2,"Mem":256}`),
+		"test_in/1.txt":  []byte("1\n"),
+		"test_out/1.txt": []byte("2\n"),
+	}
+	if err := cache.Store("yukicoder", "1", files, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	clearFunc, err := tmpChdir(workDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clearFunc()
+
+	ui := new(cli.MockUi)
+	c := &GetCommand{Meta: Meta{UI: ui}, Cache: cache}
+	if code := c.Run([]string{"-offline", "1"}); code != ExitCodeOK {
+		t.Fatalf("bad status code = %v; want %v\n%s", code, ExitCodeOK, ui.ErrorWriter.String())
+	}
+
+	if b, err := ioutil.ReadFile(filepath.Join("1", "test_out", "1.txt")); err != nil || string(b) != "2\n" {
+		t.Errorf("restored test case = %q, %v", b, err)
+	}
+
+	ui = new(cli.MockUi)
+	c = &GetCommand{Meta: Meta{UI: ui}, Cache: cache}
+	if code := c.Run([]string{"-offline", "2"}); code != ExitCodeFailed || !strings.Contains(ui.ErrorWriter.String(), "キャッシュされていません") {
+		t.Errorf("bad status code = %v; want %v\n%s", code, ExitCodeFailed, ui.ErrorWriter.String())
+	}
+}
+
+func TestGetCommandRoot(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cacheDir)
+	_, clearDirs, err := tmpUserDirs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clearDirs()
+
+	root, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	defer setEnv(RootEnv, "")()
+
+	cache := &Cache{Dir: cacheDir}
+	files := map[string][]byte{
+		InfoFile:         []byte(`{"No":"1","Name":"hoge","Time":2,"Mem":256}`),
+		"test_in/1.txt":  []byte("1\n"),
+		"test_out/1.txt": []byte("2\n"),
+	}
+	if err := cache.Store("yukicoder", "1", files, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := new(cli.MockUi)
+	c := &GetCommand{Meta: Meta{UI: ui, Config: &Config{Root: root}}, Cache: cache}
+	if code := c.Run([]string{"-offline", "1"}); code != ExitCodeOK {
+		t.Fatalf("bad status code = %v; want %v\n%s", code, ExitCodeOK, ui.ErrorWriter.String())
+	}
+
+	dir := filepath.Join(root, "yukicoder", "1")
+	if b, err := ioutil.ReadFile(filepath.Join(dir, "test_out", "1.txt")); err != nil || string(b) != "2\n" {
+		t.Errorf("restored test case = %q, %v", b, err)
+	}
+
+	m := &Meta{Config: &Config{Root: root}}
+	if d, err := ProblemDir(m.root(), "1"); err != nil || d != dir {
+		t.Errorf("ProblemDir(1) = %s, %v; want %s", d, err, dir)
+	}
+}
@@ -0,0 +1,395 @@
+package command
+
+// enCatalog is the English message catalog
+var enCatalog = map[string]string{
+	"add.synopsis": "Add a custom test case",
+	"add.help": `
+Add a custom test case to the problem specified by problem_no
+The input is read from the -input file, $EDITOR (-editor) or the standard input in this order
+
+Usage:
+	goyuki add problem_no
+
+Options:
+	-input=file, -i		Specify the input file
+	-output=file, -o		Specify the expected output file
+	-ref=file, -r			Specify the source file of the solution which generates the expected output
+	-language=lang, -l		Specify the language of -ref (default: detected from the extension)
+	-name=name, -n		Test case name (default: serial number)
+	-editor, -e			Edit the input and the output with $EDITOR
+	-verbose, -vb		Show the standard output and error of the compiler
+
+
+`,
+
+	"config.synopsis": "Show or change the settings",
+	"config.help": `
+Show or change the settings in ~/.config/goyuki/config.toml
+The settings are used as the defaults of all commands
+
+Usage:
+	goyuki config list
+	goyuki config get key
+	goyuki config set key value
+	goyuki config set key		(reset to the default)
+
+Keys:
+	language		Language created by new (e.g. cpp)
+	validater		Validater of run (default: diff)
+	color			Colored output: auto, always, never (default: auto)
+				auto colors the output to a terminal if $NO_COLOR is not set
+				The -color=mode option of all commands takes precedence
+	parallel		Number of concurrent downloads of get and mirror (default: 4)
+	root			Workspace to store the problems in root/yukicoder/problem_no (default: current directory)
+	format			Output format of run results: text, json (default: text)
+	locale			Language of the messages: ja, en (default: detected from $LANG)
+
+`,
+
+	"get.synopsis": "Download the test cases",
+	"get.help": `
+Download the test cases of the problem specified by problem_no
+into the current directory
+If the workspace ($GOYUKI_ROOT or root in config) is set, they are stored in root/yukicoder/problem_no
+The problem information is taken from yukicoder API, or from the problem page if it fails
+The test cases are downloaded from the API if $GOYUKI_TOKEN is set
+The credentials saved by goyuki login are used if $GOYUKI and $GOYUKI_TOKEN are not set
+Only the sample cases in the statement are downloaded without the credentials
+
+Only the sample cases are downloaded for AtCoder problems (abc123_a or the task url)
+and Codeforces problems (1234A or the problem url)
+
+Downloaded problems are kept in the cache (~/.cache/goyuki)
+and restored from it if the download fails or -offline is given
+
+Usage:
+	goyuki get problem_no
+	goyuki get problem_url
+	goyuki get atcoder_task_id
+	goyuki get codeforces_problem_id
+	goyuki get -contest contest_id
+
+Options:
+	-samples, -s		Download the sample cases in the statement only (no login required)
+	-update, -u		Update the existing problem directory (added test cases and files are kept)
+	-contest=id, -c		Download all problems of the contest
+	-alias, -a		Store the contest problems under the contest_id directory with A, B, C... aliases
+	-parallel=n, -j		Number of concurrent downloads of the contest problems (default: parallel in config, 4)
+	-timeout=duration		Time limit of a request (default: 30s)
+	-retry=n			Number of retries on network and 5xx errors (default: 3)
+	-offline, -o		Restore the problem from the cache without downloading
+
+`,
+
+	"history.synopsis": "Show the history of the test runs",
+	"history.help": `
+Show the history of the tests run by run
+The history is stored in ~/.local/share/goyuki/history.db
+If problem_no is given, show the runs of the problem, how the verdict changed and the first accepted run
+
+Usage:
+	goyuki history [problem_no]
+	goyuki history -show id
+	goyuki history -source id
+
+Options:
+	-show=id, -s			Show the results of the test cases of the run
+	-source=id			Show the source code of the run
+
+
+`,
+
+	"login.synopsis": "Save the yukicoder credentials",
+	"login.help": `
+Check and save the yukicoder cookie (REVEL_SESSION) and API token
+They are prompted for if no option is given
+The saved credentials are used by get and submit ($GOYUKI and $GOYUKI_TOKEN take precedence)
+
+Usage:
+	goyuki login [options]
+
+Options:
+	-cookie=value, -c		Value of REVEL_SESSION cookie
+	-token=value, -t		API token
+
+
+`,
+
+	"logout.synopsis": "Remove the saved yukicoder credentials",
+	"logout.help": `
+Remove the credentials saved by login
+
+Usage:
+	goyuki logout
+
+
+`,
+
+	"mirror.synopsis": "Download problems into the cache",
+	"mirror.help": `
+Download the given problems or the problems in the level range into the cache (~/.cache/goyuki)
+The cached problems can be restored by goyuki get -offline
+
+Usage:
+	goyuki mirror problem_no...
+	goyuki mirror -level min-max
+
+Options:
+	-level=min-max		Download the problems of level min to max (e.g. 1-2.5)
+	-parallel=n, -j		Number of concurrent downloads (default: parallel in config, 4)
+	-force, -f		Download the cached problems again
+	-samples, -s		Download the sample cases in the statement only (no login required)
+	-timeout=duration		Time limit of a request (default: 30s)
+	-retry=n			Number of retries on network and 5xx errors (default: 3)
+
+`,
+
+	"new.synopsis": "Create a source file from the template",
+	"new.help": `
+Create the source file of the problem specified by problem_no from the template of the language
+Templates are placed in ~/.config/goyuki/templates/language.tmpl (e.g. cpp.tmpl)
+in text/template format
+
+Values available in the template:
+	{{.Name}} {{.URL}} {{.No}} {{.Number}} {{.Level}} {{.Time}} {{.Mem}}
+	{{.Author}} {{.Contest}} {{.Lang}} {{.Date}}
+
+Usage:
+	goyuki new -l lang problem_no
+
+Options:
+	-language=lang, -l		Specify the language (default: language in config)
+	-output=file, -o		File to create (default: problem directory/main.language)
+	-get, -g			Download the problem by get if the directory does not exist
+	-force, -f			Overwrite the existing file
+
+
+`,
+
+	"run.synopsis": "Compile and run the tests",
+	"run.help": `
+Compile source_file and run the tests of the problem specified by problem_no
+problem_no can be a problem directory, a problem number or a problem url
+The results and a copy of source_file are recorded in the history (see goyuki history)
+
+Usage:
+	goyuki run problem_no source_file
+	goyuki run problem_no (source in goyuki.toml is used)
+
+Options:
+	-language=lang, -l		Specify the language (default: detected from the extension)
+	-validater=validater, -V      Specify how to compare the output (default: diff validater)
+	-verbose, -vb		Show the standard output and error of the compiler and the program
+	-place=n, -p			Round the numbers to n decimal places (float validater only) (0<=n<=15)
+	-rootfs=dir			Compile and run in the root filesystem extracted in dir (linux only)
+	-tolerance=x			Allowed absolute or relative error (float validater only) (default: Tolerance in info.json)
+	-time-scale=x			Multiply the time limit by x
+	-checker=file			Judge the output by the checker (run with the input, expected and actual output files, AC on exit status 0)
+	-format=format		Output format of the results: text, json (default: format in config, text)
+
+If goyuki.toml exists in the problem directory, its settings are used as the option defaults
+Options given on the command line take precedence
+
+	language = "cpp"
+	source = "main.cpp"
+	validater = "float"
+	place = 6
+	tolerance = 1e-6
+	time_scale = 2.0
+	checker = "checker.cpp"
+
+
+`,
+
+	"show.synopsis": "Show the problem statement",
+	"show.help": `
+Show the problem statement (statement.md) saved by get in the terminal
+problem_no can be a problem directory, a problem number or a problem url
+
+Usage:
+	goyuki show problem_no
+
+Options:
+	-raw			Show the Markdown as is
+
+
+`,
+
+	"stress.synopsis": "Compare the output with the brute-force solution on random tests",
+	"stress.help": `
+Compare the outputs of source_file and the brute-force solution for the inputs made by the generator
+and save the first counterexample as a test case of problem_no
+The seed is passed to the generator as the first argument
+The generator and the brute-force solution have the same time limit as source_file
+
+Usage:
+	goyuki stress problem_no source_file -brute brute_file -gen generator_file
+
+Options:
+	-brute=file, -b			Specify the source file of the brute-force solution (required)
+	-gen=file, -g			Specify the source file of the input generator (required)
+	-language=lang, -l		Specify the language (default: detected from the extension)
+	-validater=validater, -V      Specify how to compare the output (default: diff validater)
+	-verbose, -vb		Show the standard output and error of the compiler and the program
+	-place=n, -p			Round the numbers to n decimal places (float validater only) (0<=n<=15)
+	-n=count			Number of trials (default: 1000, 0 until a counterexample is found)
+	-seed=n			First seed (default: 1)
+
+
+`,
+
+	"submit.synopsis": "Submit to yukicoder",
+	"submit.help": `
+Submit source_file to the problem specified by problem_no and show the judge result
+The yukicoder API token must be set in $GOYUKI_TOKEN or saved by goyuki login
+The REVEL_SESSION cookie can't be used because the source is submitted through API
+
+Usage:
+	goyuki submit problem_no source_file
+
+Options:
+	-language=lang, -l		Specify the language (default: detected from the extension)
+	-interval=duration		Interval to check the judge result (default: 2s)
+	-timeout=duration		Time to wait for the judge result (default: 5m)
+
+
+`,
+
+	"whoami.synopsis": "Check the yukicoder credentials in use",
+	"whoami.help": `
+Show where the credentials in use (cookie and API token) come from and whether they are valid
+
+Usage:
+	goyuki whoami
+
+
+`,
+
+	"result.problem":     "\nProblem:\t%s",
+	"result.date":        "Date:\t\t%s",
+	"result.language":    "Language:\t%s",
+	"result.compileTime": "Compile time:\t%d ms",
+	"result.codeLength":  "Code length:\t%d byte",
+	"result.judgeType":   "Judge type:\t%s\n",
+	"result.custom":      "\nCustom cases:",
+	"result.summary":     "\nSummary:\t%s (%d cases)",
+
+	"submission.problem":  "\nProblem:\tNo.%s",
+	"submission.id":       "Submission:\t%d",
+	"submission.language": "Language:\t%s",
+	"submission.result":   "Result:\t\t%s\n",
+
+	"login.cookie": "REVEL_SESSION cookie (empty to skip):",
+	"login.token":  "API token (empty to skip):",
+
+	"whoami.unset":   "not set",
+	"whoami.invalid": "invalid",
+	"whoami.valid":   "valid",
+
+	"show.image": "[image: %s]",
+
+	"config.broken":        "%v\nfix or remove %s",
+	"config.useDefault":    "%v: use default config",
+	"config.invalidKey":    "Invalid key: %s",
+	"config.invalidValue":  "Invalid %s: %s",
+	"config.invalidChoice": "Invalid %s: %s (%s)",
+
+	"problemconfig.invalidValue": "invalid %s in %s: %s",
+
+	"history.empty":    "No runs recorded",
+	"history.progress": "\nProgress:\t%s",
+	"history.firstAC":  "First AC:\t#%d (%s, run %d)",
+	"history.noAC":     "First AC:\tnot accepted yet (%d runs)",
+
+	"error.invalidOption":      "Invalid option: %s",
+	"error.invalidArguments":   "Invalid arguments: %s",
+	"error.noDirectory":        "does not exist (No such directory)",
+	"error.invalidLanguage":    "Invalid language: %s",
+	"error.invalidParallel":    "Invalid parallel: %d",
+	"error.samplesOnly":        "$GOYUKI not set: download sample cases only (run goyuki login)",
+	"error.unsupportedProblem": "unsupported problem: %s",
+	"error.problemID":          "problem id %d: %v",
+	"error.downloadFailed":     "%s\tfailed: %v",
+	"error.problemNotFound":    "the problem does not exist",
+	"error.loginRequired":      "please log in to yukicoder",
+	"error.invalidCookie":      "invalid cookie: please log in to yukicoder",
+	"error.invalidRound":       "Invalid round: %d",
+	"error.invalidValidater":   "Invalid validater: %s",
+	"error.invalidColor":       "Invalid color: %s (auto, always, never)",
+	"error.invalidRootfs":      "Invalid rootfs: %s",
+	"error.rootfsIsRoot":       "rootfs must not be the root directory: %s",
+
+	"add.exclusive":  "Invalid options: -output and -ref are exclusive",
+	"add.noOutput":   "expected output required: use -output, -ref or -editor",
+	"add.readInput":  "failed to read input: %v",
+	"add.makeOutput": "failed to make output: %v",
+	"add.added":      "test case added: %s",
+
+	"get.invalidRetry": "Invalid retry: %d",
+	"get.exists":       "Cannot create directory %s: file exists (use -update)",
+	"get.noCache":      "cache is not available",
+	"get.restore":      "%v: restore %s from cache",
+	"get.cacheFailed":  "failed to cache %s: %v",
+	"get.skipped":      "%s: skipped non-testcase files: %s",
+	"get.contest":      "%s: %d problems",
+	"get.downloaded":   "%d/%d problems downloaded",
+	"get.upToDate":     "%s: already up to date",
+	"get.added":        "added\t%s",
+	"get.changed":      "changed\t%s",
+	"get.removed":      "removed\t%s",
+	"get.updated":      "%s: %d added, %d changed, %d removed",
+
+	"mirror.invalidLevel": "Invalid level: %s",
+	"mirror.cached":       "No.%s\tcached",
+	"mirror.done":         "%d/%d problems cached in %s",
+
+	"history.notFound": "run not found: %d",
+
+	"login.noCredentials": "no credentials given",
+	"login.saveFailed":    "failed to save credentials: %v",
+	"login.done":          "logged in: credentials saved to %s",
+	"logout.notLoggedIn":  "not logged in",
+	"logout.done":         "logged out",
+	"whoami.notLoggedIn":  "not logged in: run goyuki login",
+
+	"new.noProblem":  "%s does not exist (use -get)",
+	"new.exists":     "%s: file exists (use -force)",
+	"new.template":   "template error: %v",
+	"new.noTemplate": "template not found: %s: create empty file",
+	"new.created":    "created %s",
+
+	"run.invalidTolerance": "Invalid tolerance: %v",
+	"run.invalidScale":     "Invalid time scale: %v",
+	"run.invalidFormat":    "Invalid format: %s",
+	"run.checker":          "checker: %v",
+	"run.history":          "history: %v",
+
+	"show.noStatement": "statement not found: run goyuki get -update %s",
+
+	"stress.invalidCount": "Invalid count: %d",
+	"stress.normalOnly":   "stress test supports normal judge only",
+	"stress.generator":    "generator error: %v",
+	"stress.bruteForce":   "brute-force error: %v",
+	"stress.result":       "%s\tseed %d",
+	"stress.saved":        "counterexample saved: %s",
+
+	"submit.cookieOnly": "$GOYUKI_TOKEN not set: submit requires the API token, the REVEL_SESSION cookie can't be used (run goyuki login -token)",
+	"submit.noToken":    "$GOYUKI_TOKEN not set: run goyuki login",
+	"submit.readSource": "failed to read source file: %v",
+	"submit.submitted":  "submitted: %s/submissions/%d",
+
+	"yukicoder.judgeCode":    "judge code requires log in: run as normal judge",
+	"yukicoder.pageFallback": "%v: use problem page instead",
+	"yukicoder.defaultLimit": "%v: use default time and memory limit",
+
+	"api.contestNotFound":    "the contest does not exist",
+	"api.submissionNotFound": "the submission does not exist",
+	"api.unauthorized":       "api authorization failed: run goyuki login or set valid token to $GOYUKI_TOKEN",
+	"cache.notCached":        "%s %s is not cached",
+
+	"testcase.noOutput":  "missing output file: %s",
+	"testcase.noInput":   "missing input file: %s",
+	"testcase.duplicate": "duplicate test case file: %s (%s is used)",
+	"testcase.input":     "input testcase error: %v",
+	"testcase.output":    "output testcase error: %v",
+}
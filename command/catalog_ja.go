@@ -0,0 +1,395 @@
+package command
+
+// jaCatalog is the Japanese message catalog
+var jaCatalog = map[string]string{
+	"add.synopsis": "独自のテストケースを追加する",
+	"add.help": `
+problem_noで指定された番号の問題に独自のテストケースを追加する
+入力は-inputのファイル、$EDITOR(-editor)、標準入力の順に取得する
+
+Usage:
+	goyuki add problem_no
+
+Options:
+	-input=file, -i		入力ファイルを指定します
+	-output=file, -o		期待する出力ファイルを指定します
+	-ref=file, -r			期待する出力を生成する解答のソースファイルを指定します
+	-language=lang, -l		-refの言語を指定します (デフォルト 拡張子から判別)
+	-name=name, -n		テストケース名 (デフォルト 連番)
+	-editor, -e			$EDITORで入力、出力を編集する
+	-verbose, -vb		コンパイル時の標準出力、標準エラー出力を表示する
+
+
+`,
+
+	"config.synopsis": "設定を表示、変更する",
+	"config.help": `
+~/.config/goyuki/config.tomlの設定を表示、変更する
+設定は全てのコマンドのデフォルトとして使われる
+
+Usage:
+	goyuki config list
+	goyuki config get key
+	goyuki config set key value
+	goyuki config set key		(デフォルトに戻す)
+
+Keys:
+	language		newで作成する言語 (例 cpp)
+	validater		runのテストの一致方法 (デフォルト diff)
+	color			色付きで出力するか auto, always, never (デフォルト auto)
+				autoは端末への出力で$NO_COLORが未設定の場合のみ色を付ける
+				全てのコマンドの-color=modeオプションが優先される
+	parallel		get, mirrorで問題を同時に取得する数 (デフォルト 4)
+	root			問題を保存するワークスペース root/yukicoder/問題番号 (デフォルト カレントディレクトリ)
+	format			runの結果の出力形式 text, json (デフォルト text)
+	locale			メッセージの言語 ja, en (デフォルト $LANGから判別)
+
+`,
+
+	"get.synopsis": "テストケースを取得する",
+	"get.help": `
+problem_noで指定された番号の問題のテストケースを取得し、
+カレントディレクトリに展開する
+ワークスペース($GOYUKI_ROOTまたはconfigのroot)を設定した場合はroot/yukicoder/問題番号に展開する
+問題の情報はyukicoder APIから取得し、失敗した場合は問題ページから取得する
+$GOYUKI_TOKENが設定されている場合はAPIからテストケースを取得する
+$GOYUKI、$GOYUKI_TOKENが設定されていない場合はgoyuki loginで保存した認証情報を使う
+認証情報がない場合は問題文のサンプルケースのみ取得する
+
+AtCoderの問題(abc123_aまたは問題のURL)、Codeforcesの問題(1234Aまたは問題のURL)は
+問題文のサンプルケースのみ取得する
+
+取得した問題はキャッシュ(~/.cache/goyuki)に保存され、
+ダウンロードに失敗した場合、-offlineを指定した場合はキャッシュから復元する
+
+Usage:
+	goyuki get problem_no
+	goyuki get problem_url
+	goyuki get atcoder_task_id
+	goyuki get codeforces_problem_id
+	goyuki get -contest contest_id
+
+Options:
+	-samples, -s		問題文のサンプルケースのみ取得する (ログイン不要)
+	-update, -u		既存の問題ディレクトリを更新する (追加したテストケース、ファイルは残す)
+	-contest=id, -c		コンテストの全問題を取得する
+	-alias, -a		コンテストの問題をcontest_idディレクトリ以下に保存し、A, B, C...の別名を作成する
+	-parallel=n, -j		コンテストの問題を同時に取得する数 (デフォルト configのparallel、4)
+	-timeout=duration		1回のリクエストの制限時間 (デフォルト 30s)
+	-retry=n			通信エラー、5xxエラーの場合に再試行する回数 (デフォルト 3)
+	-offline, -o		ダウンロードせずにキャッシュから問題を復元する
+
+`,
+
+	"history.synopsis": "テストの実行履歴を表示する",
+	"history.help": `
+runで実行したテストの履歴を表示する
+履歴は~/.local/share/goyuki/history.dbに保存される
+problem_noを指定した場合はその問題の履歴と、結果の推移、初めてACした実行を表示する
+
+Usage:
+	goyuki history [problem_no]
+	goyuki history -show id
+	goyuki history -source id
+
+Options:
+	-show=id, -s			実行の各テストケースの結果を表示する
+	-source=id			実行したソースコードを表示する
+
+
+`,
+
+	"login.synopsis": "yukicoderの認証情報を保存する",
+	"login.help": `
+yukicoderのCookie(REVEL_SESSION)、APIトークンを確認して保存する
+オプションを指定しない場合は入力を求める
+保存した認証情報はget、submitで使われる ($GOYUKI、$GOYUKI_TOKENが優先される)
+
+Usage:
+	goyuki login [options]
+
+Options:
+	-cookie=value, -c		REVEL_SESSION cookieの値
+	-token=value, -t		APIトークン
+
+
+`,
+
+	"logout.synopsis": "保存したyukicoderの認証情報を削除する",
+	"logout.help": `
+loginで保存した認証情報を削除する
+
+Usage:
+	goyuki logout
+
+
+`,
+
+	"mirror.synopsis": "問題をまとめてキャッシュに取得する",
+	"mirror.help": `
+指定した問題、難易度の範囲の問題をまとめてキャッシュ(~/.cache/goyuki)に取得する
+キャッシュした問題はgoyuki get -offlineで復元できる
+
+Usage:
+	goyuki mirror problem_no...
+	goyuki mirror -level min-max
+
+Options:
+	-level=min-max		難易度がmin以上max以下の問題を取得する (例 1-2.5)
+	-parallel=n, -j		問題を同時に取得する数 (デフォルト configのparallel、4)
+	-force, -f		キャッシュ済みの問題も取得し直す
+	-samples, -s		問題文のサンプルケースのみ取得する (ログイン不要)
+	-timeout=duration		1回のリクエストの制限時間 (デフォルト 30s)
+	-retry=n			通信エラー、5xxエラーの場合に再試行する回数 (デフォルト 3)
+
+`,
+
+	"new.synopsis": "テンプレートからソースファイルを作成する",
+	"new.help": `
+problem_noで指定された問題のソースファイルを言語ごとのテンプレートから作成する
+テンプレートは~/.config/goyuki/templates/言語名.tmpl (例 cpp.tmpl)に
+text/templateの形式で置く
+
+テンプレートで使える値:
+	{{.Name}} {{.URL}} {{.No}} {{.Number}} {{.Level}} {{.Time}} {{.Mem}}
+	{{.Author}} {{.Contest}} {{.Lang}} {{.Date}}
+
+Usage:
+	goyuki new -l lang problem_no
+
+Options:
+	-language=lang, -l		作成する言語を指定します (デフォルト configのlanguage)
+	-output=file, -o		作成するファイル (デフォルト 問題のディレクトリ/main.言語名)
+	-get, -g			問題のディレクトリがない場合はgetで取得する
+	-force, -f			既存のファイルを上書きする
+
+
+`,
+
+	"run.synopsis": "コンパイル後、テストを実行する",
+	"run.help": `
+source_fileをコンパイル後、problem_noで指定された番号の問題のテストを実行する
+problem_noには問題のディレクトリ、問題番号、問題のURLを指定できる
+結果とsource_fileのコピーは履歴に保存される (goyuki history参照)
+
+Usage:
+	goyuki run problem_no source_file
+	goyuki run problem_no (goyuki.tomlのsourceを使う)
+
+Options:
+	-language=lang, -l		実行する言語を指定します (デフォルト 拡張子から判別)
+	-validater=validater, -V      テストの一致方法を指定します (デフォルト diff validater)
+	-verbose, -vb		コンパイル時、実行時の標準出力、標準エラー出力を表示する
+	-place=n, -p			出力される数値を小数点以下n桁に丸める (float validater時のみ) (0<=n<=15)
+	-rootfs=dir			展開したルートファイルシステムdirの中でコンパイル、実行する (linuxのみ)
+	-tolerance=x			許容する絶対誤差または相対誤差 (float validater時のみ) (デフォルト info.jsonのTolerance)
+	-time-scale=x			実行時間制限をx倍にする
+	-checker=file			出力をチェッカーfileで判定する (入力、想定解、出力のファイルを引数に実行し終了コード0でAC)
+	-format=format		結果の出力形式 text, json (デフォルト configのformat、text)
+
+問題のディレクトリにgoyuki.tomlがある場合は、その設定をオプションのデフォルトとして使う
+コマンドラインで指定したオプションが優先される
+
+	language = "cpp"
+	source = "main.cpp"
+	validater = "float"
+	place = 6
+	tolerance = 1e-6
+	time_scale = 2.0
+	checker = "checker.cpp"
+
+
+`,
+
+	"show.synopsis": "問題文を表示する",
+	"show.help": `
+getで保存した問題文(statement.md)をターミナルに表示する
+problem_noには問題のディレクトリ、問題番号、問題のURLを指定できる
+
+Usage:
+	goyuki show problem_no
+
+Options:
+	-raw			Markdownをそのまま表示する
+
+
+`,
+
+	"stress.synopsis": "ランダムテストで愚直解と出力を比較する",
+	"stress.help": `
+generatorで生成した入力に対してsource_fileとbrute-force解の出力を比較し、
+最初に見つかった反例をproblem_noのテストケースとして保存する
+generatorには第1引数としてシード値が渡される
+generatorと愚直解にもsource_fileと同じ実行時間制限が適用される
+
+Usage:
+	goyuki stress problem_no source_file -brute brute_file -gen generator_file
+
+Options:
+	-brute=file, -b			愚直解のソースファイルを指定します (必須)
+	-gen=file, -g			入力生成器のソースファイルを指定します (必須)
+	-language=lang, -l		実行する言語を指定します (デフォルト 拡張子から判別)
+	-validater=validater, -V      テストの一致方法を指定します (デフォルト diff validater)
+	-verbose, -vb		コンパイル時、実行時の標準出力、標準エラー出力を表示する
+	-place=n, -p			出力される数値を小数点以下n桁に丸める (float validater時のみ) (0<=n<=15)
+	-n=count			試行回数 (デフォルト 1000, 0で反例が見つかるまで)
+	-seed=n			最初のシード値 (デフォルト 1)
+
+
+`,
+
+	"submit.synopsis": "yukicoderに提出する",
+	"submit.help": `
+source_fileをproblem_noで指定された番号の問題に提出し、ジャッジ結果を表示する
+$GOYUKI_TOKENにyukicoderのAPIトークンを設定するか、goyuki loginでAPIトークンを保存する必要がある
+提出はAPIで行うため、REVEL_SESSION cookieだけでは提出できない
+
+Usage:
+	goyuki submit problem_no source_file
+
+Options:
+	-language=lang, -l		提出する言語を指定します (デフォルト 拡張子から判別)
+	-interval=duration		ジャッジ結果を確認する間隔 (デフォルト 2s)
+	-timeout=duration		ジャッジ結果を待つ時間 (デフォルト 5m)
+
+
+`,
+
+	"whoami.synopsis": "使用中のyukicoderの認証情報を確認する",
+	"whoami.help": `
+使用中の認証情報(Cookie、APIトークン)の取得元と有効かどうかを表示する
+
+Usage:
+	goyuki whoami
+
+
+`,
+
+	"result.problem":     "\n問題:\t\t%s",
+	"result.date":        "テスト日時:\t%s",
+	"result.language":    "言語:\t\t%s",
+	"result.compileTime": "コンパイル時間:\t%d ms",
+	"result.codeLength":  "コード長:\t%d byte",
+	"result.judgeType":   "ジャッジタイプ:\t%s\n",
+	"result.custom":      "\nカスタムケース:",
+	"result.summary":     "\n結果:\t\t%s (%d ケース)",
+
+	"submission.problem":  "\n問題:\t\tNo.%s",
+	"submission.id":       "提出ID:\t\t%d",
+	"submission.language": "言語:\t\t%s",
+	"submission.result":   "結果:\t\t%s\n",
+
+	"login.cookie": "REVEL_SESSION cookie (空欄で省略):",
+	"login.token":  "API token (空欄で省略):",
+
+	"whoami.unset":   "未設定",
+	"whoami.invalid": "無効",
+	"whoami.valid":   "有効",
+
+	"show.image": "[画像: %s]",
+
+	"config.broken":        "%v\n%s を修正するか削除してください",
+	"config.useDefault":    "%v: デフォルトの設定を使用します",
+	"config.invalidKey":    "不正なキーです: %s",
+	"config.invalidValue":  "不正な%sです: %s",
+	"config.invalidChoice": "不正な%sです: %s (%s)",
+
+	"problemconfig.invalidValue": "%sの値が不正です (%s): %s",
+
+	"history.empty":    "履歴がありません",
+	"history.progress": "\n推移:\t\t%s",
+	"history.firstAC":  "初AC:\t\t#%d (%s, %d回目)",
+	"history.noAC":     "初AC:\t\tまだACしていません (%d回)",
+
+	"error.invalidOption":      "不正なオプションです: %s",
+	"error.invalidArguments":   "不正な引数です: %s",
+	"error.noDirectory":        "ディレクトリが存在しません",
+	"error.invalidLanguage":    "不正な言語です: %s",
+	"error.invalidParallel":    "不正な並列数です: %d",
+	"error.samplesOnly":        "$GOYUKIが設定されていません: サンプルケースのみダウンロードします (goyuki loginを実行してください)",
+	"error.unsupportedProblem": "対応していない問題です: %s",
+	"error.problemID":          "問題ID %d: %v",
+	"error.downloadFailed":     "%s\t失敗: %v",
+	"error.problemNotFound":    "問題が存在しません",
+	"error.loginRequired":      "yukicoderにログインしてください",
+	"error.invalidCookie":      "無効なcookieです: yukicoderにログインしてください",
+	"error.invalidRound":       "不正な桁数です: %d",
+	"error.invalidValidater":   "不正なバリデータです: %s",
+	"error.invalidColor":       "不正なカラーモードです: %s (auto, always, never)",
+	"error.invalidRootfs":      "不正なrootfsです: %s",
+	"error.rootfsIsRoot":       "rootfsにルートディレクトリは使用できません: %s",
+
+	"add.exclusive":  "-outputと-refは同時に指定できません",
+	"add.noOutput":   "期待する出力が必要です: -output、-refまたは-editorを指定してください",
+	"add.readInput":  "入力を読み込めません: %v",
+	"add.makeOutput": "出力を作成できません: %v",
+	"add.added":      "テストケースを追加しました: %s",
+
+	"get.invalidRetry": "不正なリトライ回数です: %d",
+	"get.exists":       "%sを作成できません: 既に存在します (-updateで更新できます)",
+	"get.noCache":      "キャッシュが使用できません",
+	"get.restore":      "%v: %sをキャッシュから復元します",
+	"get.cacheFailed":  "%sをキャッシュできません: %v",
+	"get.skipped":      "%s: テストケースではないファイルを無視しました: %s",
+	"get.contest":      "%s: %d問",
+	"get.downloaded":   "%d/%d問をダウンロードしました",
+	"get.upToDate":     "%s: 最新です",
+	"get.added":        "追加\t%s",
+	"get.changed":      "変更\t%s",
+	"get.removed":      "削除\t%s",
+	"get.updated":      "%s: 追加 %d、変更 %d、削除 %d",
+
+	"mirror.invalidLevel": "不正なレベルです: %s",
+	"mirror.cached":       "No.%s\tキャッシュ済み",
+	"mirror.done":         "%d/%d問を%sにキャッシュしました",
+
+	"history.notFound": "実行が見つかりません: %d",
+
+	"login.noCredentials": "認証情報が入力されていません",
+	"login.saveFailed":    "認証情報を保存できません: %v",
+	"login.done":          "ログインしました: 認証情報を%sに保存しました",
+	"logout.notLoggedIn":  "ログインしていません",
+	"logout.done":         "ログアウトしました",
+	"whoami.notLoggedIn":  "ログインしていません: goyuki loginを実行してください",
+
+	"new.noProblem":  "%sが存在しません (-getでダウンロードできます)",
+	"new.exists":     "%sは既に存在します (-forceで上書きできます)",
+	"new.template":   "テンプレートのエラー: %v",
+	"new.noTemplate": "テンプレートが見つかりません: %s: 空のファイルを作成します",
+	"new.created":    "%sを作成しました",
+
+	"run.invalidTolerance": "不正な許容誤差です: %v",
+	"run.invalidScale":     "不正な時間倍率です: %v",
+	"run.invalidFormat":    "不正な出力形式です: %s",
+	"run.checker":          "チェッカー: %v",
+	"run.history":          "履歴: %v",
+
+	"show.noStatement": "問題文がありません: goyuki get -update %sを実行してください",
+
+	"stress.invalidCount": "不正な回数です: %d",
+	"stress.normalOnly":   "ストレステストは通常のジャッジのみ対応しています",
+	"stress.generator":    "ジェネレータのエラー: %v",
+	"stress.bruteForce":   "愚直解のエラー: %v",
+	"stress.result":       "%s\tシード %d",
+	"stress.saved":        "反例を保存しました: %s",
+
+	"submit.cookieOnly": "$GOYUKI_TOKENが設定されていません: 提出にはAPIトークンが必要で、REVEL_SESSION cookieは使用できません (goyuki login -tokenを実行してください)",
+	"submit.noToken":    "$GOYUKI_TOKENが設定されていません: goyuki loginを実行してください",
+	"submit.readSource": "ソースファイルを読み込めません: %v",
+	"submit.submitted":  "提出しました: %s/submissions/%d",
+
+	"yukicoder.judgeCode":    "ジャッジコードの取得にはログインが必要です: 通常のジャッジとして実行します",
+	"yukicoder.pageFallback": "%v: 問題ページを使用します",
+	"yukicoder.defaultLimit": "%v: デフォルトの実行時間制限とメモリ制限を使用します",
+
+	"api.contestNotFound":    "コンテストが存在しません",
+	"api.submissionNotFound": "提出が存在しません",
+	"api.unauthorized":       "APIの認証に失敗しました: goyuki loginを実行するか、有効なトークンを$GOYUKI_TOKENに設定してください",
+	"cache.notCached":        "%s %sはキャッシュされていません",
+
+	"testcase.noOutput":  "出力ファイルがありません: %s",
+	"testcase.noInput":   "入力ファイルがありません: %s",
+	"testcase.duplicate": "テストケース名が重複しています: %s (%sを使用します)",
+	"testcase.input":     "入力テストケースのエラー: %v",
+	"testcase.output":    "出力テストケースのエラー: %v",
+}
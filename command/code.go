@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
 	"strings"
 	"text/template"
 	"time"
@@ -17,8 +18,15 @@ import (
 type Code struct {
 	*LangCmd
 	*Info
-	Lang []string
-	Dir  string
+	Lang    []string
+	Dir     string
+	Sandbox *Sandbox
+
+	// TimeScale is the multiplier of the time limit (0 is the same as 1)
+	TimeScale float64
+
+	// memory is the peak memory usage of the last run in KB
+	memory int64
 }
 
 // Compile to compile the code
@@ -45,6 +53,10 @@ func (c *Code) buildCmd(i int, args ...string) (*exec.Cmd, error) {
 
 	com := strings.Split(b.String(), " ")
 	com = append(com, args...)
+	if c.Sandbox != nil {
+		return c.Sandbox.Command(c.Dir, com)
+	}
+
 	cmd := exec.Command(com[0], com[1:]...)
 	cmd.Dir = c.Dir
 	return cmd, nil
@@ -68,9 +80,11 @@ func (c *Code) judge(cmd *exec.Cmd, v Validater, expected []byte) string {
 		ch <- cmd.Run()
 	}()
 
+	c.memory = 0
 	select {
 	case err := <-ch:
 		t := time.Now().Sub(sTime).Nanoseconds() / 1000000
+		c.memory = maxRSS(cmd.ProcessState)
 		if err != nil {
 			return RE
 		}
@@ -78,19 +92,67 @@ func (c *Code) judge(cmd *exec.Cmd, v Validater, expected []byte) string {
 			return fmt.Sprintf("%s: %d ms", WA, t)
 		}
 		return fmt.Sprintf("%s: %d ms", AC, t)
-	case <-time.After(time.Duration(c.Info.Time) * time.Second):
+	case <-time.After(c.TimeLimit()):
 		return TLE
 	}
 }
 
+// Memory returns the peak memory usage of the last run in KB (0 if unknown)
+func (c *Code) Memory() int64 {
+	return c.memory
+}
+
+// TimeLimit returns the time limit multiplied by TimeScale
+func (c *Code) TimeLimit() time.Duration {
+	limit := time.Duration(c.Info.Time) * time.Second
+	if c.TimeScale > 0 {
+		limit = time.Duration(float64(limit) * c.TimeScale)
+	}
+	return limit
+}
+
+// Output to run the code and get its standard output.
+// The code is killed if it exceeds TimeLimit.
+func (c *Code) Output(r io.Reader, e io.Writer, args ...string) ([]byte, error) {
+	cmd, err := c.buildCmd(1, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = r, &buf, e
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("%s: %s: %v", RE, c.File, err)
+	}
+
+	var timer *time.Timer
+	if limit := c.TimeLimit(); limit > 0 {
+		timer = time.AfterFunc(limit, func() {
+			cmd.Process.Kill()
+		})
+	}
+	err = cmd.Wait()
+	if timer != nil && !timer.Stop() {
+		return nil, fmt.Errorf("%s: %s", TLE, c.File)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %v", RE, c.File, err)
+	}
+	return buf.Bytes(), nil
+}
+
 // Reactive to run the reactive format of Judge
 func (c *Code) Reactive(code *Code, inFile, outFile string, r io.Reader, w, e io.Writer) (string, error) {
-	cur, err := os.Getwd()
+	in, err := filepath.Abs(inFile)
+	if err != nil {
+		return "", err
+	}
+	out, err := filepath.Abs(outFile)
 	if err != nil {
 		return "", err
 	}
 
-	rCmd, err := c.buildCmd(1, cur+"/"+inFile, cur+"/"+outFile, code.Dir+"/"+code.LangCmd.File)
+	rCmd, err := c.buildCmd(1, in, out, filepath.Join(code.Dir, code.LangCmd.File))
 	if err != nil {
 		return "", err
 	}
@@ -114,10 +176,10 @@ func (c *Code) Reactive(code *Code, inFile, outFile string, r io.Reader, w, e io
 		cmd.Stdin, rCmd.Stdout = r, w
 	}
 	cmd.Stderr = e
-	return c.reactiveJudge(cmd, rCmd), nil
+	return c.reactiveJudge(code, cmd, rCmd), nil
 }
 
-func (c *Code) reactiveJudge(cmd, rCmd *exec.Cmd) string {
+func (c *Code) reactiveJudge(code *Code, cmd, rCmd *exec.Cmd) string {
 	ch1, ch2 := make(chan error), make(chan []error)
 	sTime := time.Now()
 	go func() {
@@ -128,9 +190,11 @@ func (c *Code) reactiveJudge(cmd, rCmd *exec.Cmd) string {
 		ch2 <- []error{<-ch1, err}
 	}()
 
+	code.memory = 0
 	select {
 	case errs := <-ch2:
 		t := time.Now().Sub(sTime).Nanoseconds() / 1000000
+		code.memory = maxRSS(cmd.ProcessState)
 		if errs[0] != nil {
 			return RE
 		}
@@ -138,13 +202,14 @@ func (c *Code) reactiveJudge(cmd, rCmd *exec.Cmd) string {
 			return fmt.Sprintf("%s: %d ms", WA, t)
 		}
 		return fmt.Sprintf("%s: %d ms", AC, t)
-	case <-time.After(time.Duration(c.Info.Time) * time.Second):
+	case <-time.After(c.TimeLimit()):
 		return TLE
 	}
 }
 
-// NewCode to get the compiled code
-func NewCode(f string, lang []string, i *Info, w, e io.Writer) (*Code, *Result, func(), error) {
+// NewCode to get the compiled code.
+// The code is compiled and run in sb if it is not nil.
+func NewCode(f string, lang []string, i *Info, sb *Sandbox, w, e io.Writer) (*Code, *Result, func(), error) {
 	dir, err := ioutil.TempDir("", "goyuki")
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("can't create directory: %v", err)
@@ -181,6 +246,7 @@ func NewCode(f string, lang []string, i *Info, w, e io.Writer) (*Code, *Result,
 		Lang:    lang,
 		Info:    i,
 		Dir:     dir,
+		Sandbox: sb,
 	}
 
 	sTime := time.Now()
@@ -200,7 +266,7 @@ func NewCode(f string, lang []string, i *Info, w, e io.Writer) (*Code, *Result,
 }
 
 // NewReactiveCode to get the compiled reactive code
-func NewReactiveCode(info *Info, no string, w, e io.Writer) (*Code, func(), error) {
+func NewReactiveCode(info *Info, no string, sb *Sandbox, w, e io.Writer) (*Code, func(), error) {
 	dir, err := ioutil.TempDir("", "goyuki")
 	if err != nil {
 		return nil, nil, fmt.Errorf("can't create directory: %v", err)
@@ -235,6 +301,7 @@ func NewReactiveCode(info *Info, no string, w, e io.Writer) (*Code, func(), erro
 		Lang:    lang,
 		Info:    info,
 		Dir:     dir,
+		Sandbox: sb,
 	}
 
 	if err := code.Compile(os.Stdin, w, e); err != nil {
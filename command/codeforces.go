@@ -0,0 +1,211 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// CodeforcesURL is Codeforces contest url
+const CodeforcesURL = "https://codeforces.com/contest"
+
+// Codeforces is the Provider of Codeforces.
+// Only the sample cases in the problem statement are available.
+// The problem id is the contest id followed by the problem index (1234A).
+type Codeforces struct {
+	// HTTP sends the requests to the site (DefaultHTTPClient if nil)
+	HTTP *HTTPClient
+
+	mu    sync.Mutex
+	pages map[string][]byte
+}
+
+var (
+	codeforcesID  = regexp.MustCompile(`^(\d+)([A-Z]\d?)$`)
+	codeforcesURL = regexp.MustCompile(`^(?:https?://)?(?:www\.)?codeforces\.com/(?:contest/(\d+)/problem|problemset/problem/(\d+))/([A-Z]\d?)/?$`)
+	codeforcesTL  = regexp.MustCompile(`([\d.]+)\s*seconds?`)
+	codeforcesML  = regexp.MustCompile(`(\d+)\s*megabytes?`)
+)
+
+// Name returns the site name
+func (cf *Codeforces) Name() string {
+	return "codeforces"
+}
+
+// Parse accepts a problem id (1234A), a contest problem url or a problemset problem url
+func (cf *Codeforces) Parse(spec string) (string, bool, error) {
+	if codeforcesID.MatchString(spec) {
+		return spec, true, nil
+	}
+
+	if m := codeforcesURL.FindStringSubmatch(spec); m != nil {
+		return m[1] + m[2] + m[3], true, nil
+	}
+	return "", false, nil
+}
+
+// Info gets the problem infomation from the problem page
+func (cf *Codeforces) Info(id string) (*Info, error) {
+	client := cf.HTTP
+	if client == nil {
+		client = DefaultHTTPClient
+	}
+
+	res, err := client.Get(cf.URL(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed problem request: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, newMsgError("error.problemNotFound")
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed problem request: %s", res.Status)
+	}
+
+	page, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	i, err := parseCodeforces(bytes.NewReader(page))
+	if err != nil {
+		return nil, err
+	}
+	i.No, i.Contest = id, codeforcesID.FindStringSubmatch(id)[1]
+
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+	if cf.pages == nil {
+		cf.pages = map[string][]byte{}
+	}
+	cf.pages[id] = page
+	return i, nil
+}
+
+// TestCases gets the sample cases from the problem page
+func (cf *Codeforces) TestCases(id string, i *Info) (map[string][]byte, error) {
+	cf.mu.Lock()
+	page := cf.pages[id]
+	cf.mu.Unlock()
+
+	samples, err := parseCodeforcesSamples(bytes.NewReader(page))
+	if err != nil {
+		return nil, err
+	}
+	return sampleFiles(samples), nil
+}
+
+// JudgeCode is not available on Codeforces
+func (cf *Codeforces) JudgeCode(id string, i *Info) ([]byte, error) {
+	return nil, fmt.Errorf("judge code is not available on Codeforces")
+}
+
+// Statement returns the problem page downloaded by Info
+func (cf *Codeforces) Statement(id string) []byte {
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+	return cf.pages[id]
+}
+
+// Forget drops the problem page downloaded by Info
+func (cf *Codeforces) Forget(id string) {
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+	delete(cf.pages, id)
+}
+
+// URL returns the problem page url
+func (cf *Codeforces) URL(id string) string {
+	m := codeforcesID.FindStringSubmatch(id)
+	if m == nil {
+		return ""
+	}
+	return strings.Join([]string{CodeforcesURL, m[1], "problem", m[2]}, "/")
+}
+
+// SamplesOnly reports whether TestCases returns the sample cases only
+func (cf *Codeforces) SamplesOnly() bool {
+	return true
+}
+
+func parseCodeforces(r io.Reader) (*Info, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("problem parse error: %v", err)
+	}
+
+	header := doc.Find("div.problem-statement div.header").First()
+	if header.Size() == 0 {
+		return nil, fmt.Errorf("problem parse error: problem statement not found")
+	}
+
+	// the title is prefixed with the problem index (A. Title)
+	name := strings.TrimSpace(header.Find("div.title").First().Text())
+	if n := strings.Index(name, ". "); n >= 0 {
+		name = name[n+2:]
+	}
+
+	i := &Info{Name: name, Time: DefaultTime, Mem: DefaultMem}
+	if m := codeforcesTL.FindStringSubmatch(header.Find("div.time-limit").Text()); m != nil {
+		if t, err := strconv.ParseFloat(m[1], 64); err == nil {
+			i.Time = int(math.Ceil(t))
+		}
+	}
+	if m := codeforcesML.FindStringSubmatch(header.Find("div.memory-limit").Text()); m != nil {
+		i.Mem, _ = strconv.Atoi(m[1])
+	}
+	return i, nil
+}
+
+func parseCodeforcesSamples(r io.Reader) ([]*Sample, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("sample parse error: %v", err)
+	}
+
+	var inputs, outputs [][]byte
+	doc.Find("div.sample-test").Each(func(n int, e *goquery.Selection) {
+		e.Find("div.input pre").Each(func(n int, pre *goquery.Selection) {
+			inputs = append(inputs, codeforcesSampleText(pre))
+		})
+		e.Find("div.output pre").Each(func(n int, pre *goquery.Selection) {
+			outputs = append(outputs, codeforcesSampleText(pre))
+		})
+	})
+
+	if len(inputs) == 0 || len(inputs) != len(outputs) {
+		return nil, fmt.Errorf("sample cases not found")
+	}
+
+	samples := make([]*Sample, len(inputs))
+	for n := range inputs {
+		samples[n] = &Sample{In: inputs[n], Out: outputs[n]}
+	}
+	return samples, nil
+}
+
+// codeforcesSampleText returns the text of the sample.
+// The lines are divided by <br> or put in div.test-example-line.
+func codeforcesSampleText(pre *goquery.Selection) []byte {
+	if lines := pre.Find("div.test-example-line"); lines.Size() > 0 {
+		var strs []string
+		lines.Each(func(n int, line *goquery.Selection) {
+			strs = append(strs, line.Text())
+		})
+		return sampleText(strings.Join(strs, "\n"))
+	}
+
+	pre.Find("br").ReplaceWithHtml("\n")
+	return sampleText(pre.Text())
+}
@@ -0,0 +1,81 @@
+package command
+
+import (
+	"os"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/mgutz/ansi"
+)
+
+// Color modes
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// UseColor reports whether the output to f is colored in the mode.
+// auto colors the output if $NO_COLOR is not set and f is a terminal.
+func UseColor(mode string, f *os.File) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return isTerminal(f)
+}
+
+// isTerminal reports whether f is a terminal
+func isTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// SetColor enables or disables the colors of the judge codes and the other output
+func SetColor(enabled bool) {
+	ansi.DisableColors(!enabled)
+
+	AC = ansi.Color("[AC]", "green+bh")
+	WA = ansi.Color("[WA]", "yellow+bh")
+	TLE = ansi.Color("[TLE]", "yellow+bh")
+	MLE = ansi.Color("[MLE]", "yellow+bh")
+	RE = ansi.Color("[RE]", "yellow+bh")
+	CE = ansi.Color("[CE]", "yellow+bh")
+}
+
+// ColorFlag removes the global -color=mode flag from args.
+// It accepts -color=mode, -color mode and the -- prefixed forms anywhere in args.
+// mode is empty if the flag is not given.
+func ColorFlag(args []string) (string, []string, error) {
+	mode := ""
+	var rest []string
+	for n := 0; n < len(args); n++ {
+		arg := args[n]
+		name := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+		if name == arg || !strings.HasPrefix(name, "color") {
+			rest = append(rest, arg)
+			continue
+		}
+
+		switch {
+		case name == "color" && n+1 < len(args):
+			n++
+			mode = args[n]
+		case strings.HasPrefix(name, "color="):
+			mode = strings.TrimPrefix(name, "color=")
+		default:
+			rest = append(rest, arg)
+			continue
+		}
+
+		if mode != ColorAuto && mode != ColorAlways && mode != ColorNever {
+			return "", nil, newMsgError("error.invalidColor", mode)
+		}
+	}
+	return mode, rest, nil
+}
@@ -0,0 +1,84 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUseColor(t *testing.T) {
+	f, err := ioutil.TempFile("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	testCases := []struct {
+		mode    string
+		noColor string
+		color   bool
+	}{
+		{ColorAlways, "", true},
+		{ColorAlways, "1", true},
+		{ColorNever, "", false},
+		{ColorAuto, "", false},
+		{ColorAuto, "1", false},
+	}
+
+	for _, testCase := range testCases {
+		clear := setEnv("NO_COLOR", testCase.noColor)
+		if color := UseColor(testCase.mode, f); color != testCase.color {
+			t.Errorf("UseColor(%s, NO_COLOR=%s) = %v; want %v", testCase.mode, testCase.noColor, color, testCase.color)
+		}
+		clear()
+	}
+}
+
+func TestSetColor(t *testing.T) {
+	defer SetColor(true)
+
+	SetColor(false)
+	if AC != "[AC]" || TLE != "[TLE]" {
+		t.Errorf("judge codes = %q, %q; want without colors", AC, TLE)
+	}
+	if md := renderMarkdown("# title\n`code`", ".", LocaleJa); md != "title\ncode" {
+		t.Errorf("renderMarkdown = %q; want without colors", md)
+	}
+
+	SetColor(true)
+	if !strings.Contains(AC, "\x1b[") {
+		t.Errorf("AC = %q; want colored", AC)
+	}
+}
+
+func TestColorFlag(t *testing.T) {
+	testCases := []struct {
+		args []string
+		mode string
+		rest []string
+		err  string
+	}{
+		{args: []string{"run", "1", "main.go"}, rest: []string{"run", "1", "main.go"}},
+		{args: []string{"-color=never", "run", "1"}, mode: "never", rest: []string{"run", "1"}},
+		{args: []string{"run", "--color", "always", "1"}, mode: "always", rest: []string{"run", "1"}},
+		{args: []string{"run", "-colors", "1"}, rest: []string{"run", "-colors", "1"}},
+		{args: []string{"run", "-color"}, rest: []string{"run", "-color"}},
+		{args: []string{"-color=red", "run"}, err: "不正なカラーモード"},
+	}
+
+	for _, testCase := range testCases {
+		mode, rest, err := ColorFlag(testCase.args)
+		if testCase.err != "" {
+			if err == nil || !strings.Contains(err.Error(), testCase.err) {
+				t.Errorf("ColorFlag(%v) error = %v; want %s", testCase.args, err, testCase.err)
+			}
+			continue
+		}
+		if err != nil || mode != testCase.mode || !reflect.DeepEqual(rest, testCase.rest) {
+			t.Errorf("ColorFlag(%v) = %s, %v, %v; want %s, %v", testCase.args, mode, rest, err, testCase.mode, testCase.rest)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConfigCommand is a Command that edits the global config
+type ConfigCommand struct {
+	Meta
+
+	// Path is used instead of ConfigPath if it is not empty
+	Path string
+}
+
+// Run gets, sets or lists the config values
+func (c *ConfigCommand) Run(args []string) int {
+	flags := c.Meta.NewFlagSet("config", c.Help())
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(c.msg("error.invalidOption", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+	args = flags.Args()
+
+	if len(args) < 1 {
+		c.UI.Error(c.msg("error.invalidArguments", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+
+	p := c.Path
+	if p == "" {
+		var err error
+		if p, err = ConfigPath(); err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+	}
+
+	conf, err := ReadConfig(p)
+	if err != nil {
+		// set would drop all values of a broken config
+		c.UI.Error(c.msg("config.broken", err, p))
+		return ExitCodeFailed
+	}
+
+	switch {
+	case args[0] == "list" && len(args) == 1:
+		current := conf.withDefaults()
+		for _, key := range ConfigKeys() {
+			v, _ := current.Get(key)
+			c.UI.Output(fmt.Sprintf("%s = %s", key, v))
+		}
+	case args[0] == "get" && len(args) == 2:
+		v, err := conf.withDefaults().Get(args[1])
+		if err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+		c.UI.Output(v)
+	case args[0] == "set" && (len(args) == 2 || len(args) == 3):
+		value := ""
+		if len(args) == 3 {
+			value = args[2]
+		}
+
+		if err := conf.Set(args[1], value); err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+		if err := conf.Write(p); err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+	default:
+		c.UI.Error(c.msg("error.invalidArguments", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+	return ExitCodeOK
+}
+
+// Synopsis is a one-line, short synopsis of the command.
+func (c *ConfigCommand) Synopsis() string {
+	return c.msg("config.synopsis")
+}
+
+// Help is a long-form help text
+func (c *ConfigCommand) Help() string {
+	return strings.TrimSpace(c.msg("config.help"))
+}
@@ -0,0 +1,122 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestConfigCommand_implement(t *testing.T) {
+	var _ cli.Command = &ConfigCommand{}
+}
+
+func TestConfigCommandFlag(t *testing.T) {
+	testCases := []struct {
+		args   []string
+		code   int
+		result string
+	}{
+		{args: []string{"-foo"}, code: ExitCodeFailed, result: "不正なオプション"},
+		{args: []string{}, code: ExitCodeFailed, result: "不正な引数"},
+		{args: []string{"foo"}, code: ExitCodeFailed, result: "不正な引数"},
+		{args: []string{"get"}, code: ExitCodeFailed, result: "不正な引数"},
+		{args: []string{"get", "foo"}, code: ExitCodeFailed, result: "不正なキー"},
+		{args: []string{"set", "color", "red"}, code: ExitCodeFailed, result: "不正なcolor"},
+	}
+
+	for _, testCase := range testCases {
+		ui := new(cli.MockUi)
+		c := &ConfigCommand{
+			Meta: Meta{
+				UI: ui,
+			},
+			Path: filepath.Join(os.TempDir(), "goyuki-none", ConfigFile),
+		}
+
+		code := c.Run(testCase.args)
+		errs := ui.ErrorWriter.String()
+
+		if code != testCase.code || !strings.Contains(errs, testCase.result) {
+			t.Errorf("bad status code = %v; want %v\nError message = %s; want %s", code, testCase.code, errs, testCase.result)
+		}
+	}
+}
+
+func TestConfigCommand(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	p := filepath.Join(dir, "goyuki", ConfigFile)
+
+	testCases := []struct {
+		args   []string
+		output string
+	}{
+		{args: []string{"get", "parallel"}, output: "4\n"},
+		{args: []string{"set", "parallel", "8"}},
+		{args: []string{"set", "language", "cpp"}},
+		{args: []string{"get", "parallel"}, output: "8\n"},
+		{args: []string{"set", "parallel"}},
+		{args: []string{"list"}, output: "language = cpp\nvalidater = diff\ncolor = auto\nparallel = 4\nroot = \nformat = text\nlocale = \n"},
+	}
+
+	for _, testCase := range testCases {
+		ui := new(cli.MockUi)
+		c := &ConfigCommand{Meta: Meta{UI: ui}, Path: p}
+
+		if code := c.Run(testCase.args); code != ExitCodeOK {
+			t.Fatalf("Run(%v) = %d; want %d\n%s", testCase.args, code, ExitCodeOK, ui.ErrorWriter.String())
+		}
+		if testCase.output == "" {
+			continue
+		}
+		if out := ui.OutputWriter.String(); out != testCase.output {
+			t.Errorf("Run(%v) output = %q; want %q", testCase.args, out, testCase.output)
+		}
+	}
+
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "language = \"cpp\"\n" {
+		t.Errorf("config file = %q; want only language", b)
+	}
+}
+
+func TestConfigCommandBrokenFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	p := filepath.Join(dir, ConfigFile)
+
+	broken := "language = \"cpp\"\nparallel = \n"
+	if err := ioutil.WriteFile(p, []byte(broken), FPerm); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := new(cli.MockUi)
+	c := &ConfigCommand{Meta: Meta{UI: ui}, Path: p}
+	if code := c.Run([]string{"set", "parallel", "8"}); code != ExitCodeFailed {
+		t.Fatalf("Run(set) = %d; want %d", code, ExitCodeFailed)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, p) {
+		t.Errorf("error = %q; want the config path", out)
+	}
+
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != broken {
+		t.Errorf("config file = %q; want %q", b, broken)
+	}
+}
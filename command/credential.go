@@ -0,0 +1,143 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CredentialFile is the file to store the credentials in the config directory
+const CredentialFile = "credentials.json"
+
+// Credential is yukicoder credentials.
+// Cookie is the value of REVEL_SESSION cookie and Token is the API token.
+type Credential struct {
+	Cookie string `json:"cookie,omitempty"`
+	Token  string `json:"token,omitempty"`
+}
+
+// ConfigDir returns goyuki config directory ($XDG_CONFIG_HOME/goyuki or ~/.config/goyuki)
+func ConfigDir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "goyuki"), nil
+	}
+
+	home := os.Getenv("HOME")
+	if home == "" {
+		return "", fmt.Errorf("$HOME not set")
+	}
+	return filepath.Join(home, ".config", "goyuki"), nil
+}
+
+// CredentialPath returns the path of the credential file
+func CredentialPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, CredentialFile), nil
+}
+
+// ReadCredential reads the stored credentials.
+// It returns empty credentials if nothing is stored.
+func ReadCredential() (*Credential, error) {
+	p, err := CredentialPath()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := ioutil.ReadFile(p)
+	if os.IsNotExist(err) {
+		return &Credential{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cred := &Credential{}
+	if err := json.Unmarshal(b, cred); err != nil {
+		return nil, fmt.Errorf("credential file parse error: %v", err)
+	}
+	return cred, nil
+}
+
+// LoadCredential returns the credentials to use.
+// $GOYUKI and $GOYUKI_TOKEN take precedence over the stored credentials.
+func LoadCredential() (*Credential, error) {
+	cred, err := ReadCredential()
+	if err != nil {
+		return nil, err
+	}
+
+	if cookie := os.Getenv("GOYUKI"); cookie != "" {
+		cred.Cookie = cookie
+	}
+	if token := os.Getenv("GOYUKI_TOKEN"); token != "" {
+		cred.Token = token
+	}
+	return cred, nil
+}
+
+// Write stores the credentials in the file readable only by the user
+func (c *Credential) Write() error {
+	p, err := CredentialPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(p, b, 0600); err != nil {
+		return err
+	}
+	// the file may have existed with looser permission
+	return os.Chmod(p, 0600)
+}
+
+// RemoveCredential removes the stored credentials
+func RemoveCredential() error {
+	p, err := CredentialPath()
+	if err != nil {
+		return err
+	}
+	return os.Remove(p)
+}
+
+// checkCookie validates the session cookie by requesting a page which requires log in
+func checkCookie(baseURL, cookie string) error {
+	uri := strings.Join([]string{baseURL, "no", "1", "testcase.zip"}, "/")
+	req, err := sessionRequest("HEAD", uri, cookie)
+	if err != nil {
+		return err
+	}
+
+	res, err := DefaultHTTPClient.Do(WithMaxRedirects(req, 0))
+	if err != nil {
+		return fmt.Errorf("failed login request: %v", err)
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case 200:
+		return nil
+	case 401, 403:
+		return newMsgError("error.invalidCookie")
+	}
+	return fmt.Errorf("login check error: %s", res.Status)
+}
+
+// checkToken validates the API token by requesting an API which requires authorization
+func checkToken(api *APIClient) error {
+	_, err := api.TestCaseFiles(1, "in")
+	return err
+}
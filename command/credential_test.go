@@ -0,0 +1,53 @@
+package command
+
+import (
+	"os"
+	"testing"
+)
+
+func TestCredential(t *testing.T) {
+	dir, clearFunc, err := tmpConfigDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clearFunc()
+	defer setEnv("GOYUKI", "")()
+	defer setEnv("GOYUKI_TOKEN", "")()
+
+	cred, err := ReadCredential()
+	if err != nil || cred.Cookie != "" || cred.Token != "" {
+		t.Fatalf("ReadCredential = %+v, %v; want empty credential", cred, err)
+	}
+
+	if err := (&Credential{Cookie: "cookie", Token: "token"}).Write(); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+
+	p, _ := CredentialPath()
+	if p != dir+"/goyuki/"+CredentialFile {
+		t.Errorf("CredentialPath = %s", p)
+	}
+	fi, err := os.Stat(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0600 {
+		t.Errorf("credential file mode = %v; want 0600", fi.Mode().Perm())
+	}
+
+	os.Setenv("GOYUKI_TOKEN", "env")
+	cred, err = LoadCredential()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cred.Cookie != "cookie" || cred.Token != "env" {
+		t.Errorf("LoadCredential = %+v; want cookie from file and token from env", cred)
+	}
+
+	if err := RemoveCredential(); err != nil {
+		t.Fatalf("RemoveCredential error: %v", err)
+	}
+	if err := RemoveCredential(); !os.IsNotExist(err) {
+		t.Errorf("RemoveCredential twice error = %v; want not exist", err)
+	}
+}
@@ -1,313 +1,467 @@
 package command
 
 import (
-	"archive/zip"
-	"bytes"
 	"encoding/json"
 	"fmt"
-	"io"
 	"io/ioutil"
-	"net/http"
 	"os"
 	"path/filepath"
-	"regexp"
-	"strconv"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
-	"github.com/PuerkitoBio/goquery"
-	"github.com/franela/goreq"
+	"github.com/mitchellh/cli"
 )
 
 // GetCommand is a Command that get test case
 type GetCommand struct {
 	Meta
+
+	// Cache keeps the downloaded problems if it is not nil
+	Cache *Cache
+
+	// Offline restores the problems from Cache without network
+	Offline bool
+
+	// HTTP sends the requests. It is made from -timeout and -retry if nil.
+	HTTP *HTTPClient
+
+	// API is used instead of yukicoder API if it is not nil
+	API *APIClient
+
+	// URL is used instead of BaseURL if it is not empty
+	URL string
 }
 
-// Info is problem info
-type Info struct {
-	No        string
-	Name      string
-	Level     int
-	Time      int
-	Mem       int
-	RLang     string
-	JudgeType int
+// Sample is a sample case in the problem statement
+type Sample struct {
+	In  []byte
+	Out []byte
 }
 
+// SamplePrefix is name prefix of the sample case
+const SamplePrefix = "sample_"
+
+// Default limits used when the problem page can't be parsed
+const (
+	DefaultTime = 2
+	DefaultMem  = 512
+)
+
 // Run get test case
 func (c *GetCommand) Run(args []string) int {
+	var (
+		samplesFlag  bool
+		contestFlag  int
+		aliasFlag    bool
+		parallelFlag int
+		updateFlag   bool
+		timeoutFlag  time.Duration
+		retryFlag    int
+		offlineFlag  bool
+	)
+
 	flags := c.Meta.NewFlagSet("get", c.Help())
+	flags.BoolVar(&samplesFlag, "s", false, "download sample cases only")
+	flags.BoolVar(&samplesFlag, "samples", false, "download sample cases only")
+	flags.BoolVar(&updateFlag, "u", false, "update existing problem directory")
+	flags.BoolVar(&updateFlag, "update", false, "update existing problem directory")
+	flags.IntVar(&contestFlag, "c", 0, "Specify contest id")
+	flags.IntVar(&contestFlag, "contest", 0, "Specify contest id")
+	flags.BoolVar(&aliasFlag, "a", false, "save contest problems under contest directory with A, B, C... aliases")
+	flags.BoolVar(&aliasFlag, "alias", false, "save contest problems under contest directory with A, B, C... aliases")
+	flags.IntVar(&parallelFlag, "j", c.config().Parallel, "Number of concurrent downloads")
+	flags.IntVar(&parallelFlag, "parallel", c.config().Parallel, "Number of concurrent downloads")
+	flags.DurationVar(&timeoutFlag, "timeout", DefaultTimeout, "Time limit of a request")
+	flags.IntVar(&retryFlag, "retry", DefaultRetry, "Number of retries of a failed request")
+	flags.BoolVar(&offlineFlag, "o", false, "restore the problem from the cache")
+	flags.BoolVar(&offlineFlag, "offline", false, "restore the problem from the cache")
 
 	if err := flags.Parse(args); err != nil {
-		msg := fmt.Sprintf("Invalid option: %s", strings.Join(args, " "))
-		c.UI.Error(msg)
+		c.UI.Error(c.msg("error.invalidOption", strings.Join(args, " ")))
 		return ExitCodeFailed
 	}
 	args = flags.Args()
 
-	if len(args) < 1 {
-		msg := fmt.Sprintf("Invalid arguments: %s", strings.Join(args, " "))
-		c.UI.Error(msg)
+	if len(args) < 1 && contestFlag == 0 {
+		c.UI.Error(c.msg("error.invalidArguments", strings.Join(args, " ")))
 		return ExitCodeFailed
 	}
 
-	cookie := os.Getenv("GOYUKI")
-	if cookie == "" {
-		c.UI.Error("$GOYUKI not set")
+	if parallelFlag < 1 {
+		c.UI.Error(c.msg("error.invalidParallel", parallelFlag))
 		return ExitCodeFailed
 	}
 
-	num, err := strconv.Atoi(args[0])
-	if err != nil {
-		c.UI.Error(err.Error())
+	if retryFlag < 0 {
+		c.UI.Error(c.msg("get.invalidRetry", retryFlag))
 		return ExitCodeFailed
 	}
+	if c.HTTP == nil {
+		c.HTTP = NewHTTPClient(timeoutFlag, retryFlag)
+	}
 
-	if _, err := os.Stat(fmt.Sprint(num)); err == nil {
-		c.UI.Error(fmt.Sprintf("Cannot create directory %d: file exists", num))
-		return ExitCodeFailed
+	if c.Cache == nil {
+		if cache, err := NewCache(); err == nil {
+			c.Cache = cache
+		}
 	}
+	c.Offline = c.Offline || offlineFlag
 
-	b, i, err := download(num, cookie)
+	cred, err := LoadCredential()
 	if err != nil {
-		c.UI.Error(err.Error())
+		c.UI.Error(c.errMsg(err))
 		return ExitCodeFailed
 	}
+	if cred.Cookie == "" && cred.Token == "" && !samplesFlag && !c.Offline {
+		c.UI.Warn(c.msg("error.samplesOnly"))
+		samplesFlag = true
+	}
+	api := c.API
+	if api == nil {
+		api = NewAPIClient(cred.Token)
+		api.HTTP = c.HTTP
+	}
+	yuki := &Yukicoder{
+		UI:      c.UI,
+		Cookie:  cred.Cookie,
+		API:     api,
+		Samples: samplesFlag,
+		HTTP:    c.HTTP,
+		Base:    c.URL,
+		Locale:  c.locale(),
+	}
+
+	if contestFlag != 0 {
+		return c.getContest(yuki, contestFlag, aliasFlag, parallelFlag, updateFlag)
+	}
 
-	rb, err := downloadReactive(i, cookie)
+	p, id, err := FindProvider([]Provider{yuki, &AtCoder{HTTP: c.HTTP}, &Codeforces{HTTP: c.HTTP}}, args[0])
 	if err != nil {
-		c.UI.Error(err.Error())
+		c.UI.Error(c.errMsg(err))
 		return ExitCodeFailed
 	}
 
-	if err := save(b, rb, i, num); err != nil {
-		c.UI.Error(err.Error())
+	if _, err := c.get(p, id, problemPath(c.root(), p.Name(), id), updateFlag); err != nil {
+		c.UI.Error(c.errMsg(err))
 		return ExitCodeFailed
 	}
-
 	return ExitCodeOK
 }
 
 // Synopsis is a one-line, short synopsis of the command.
 func (c *GetCommand) Synopsis() string {
-	return "テストケースを取得する"
+	return c.msg("get.synopsis")
 }
 
 // Help is a long-form help text
 func (c *GetCommand) Help() string {
-	helpText := `
-problem_noで指定された番号の問題のテストケースを取得し、
-カレントディレクトリに展開する
+	return strings.TrimSpace(c.msg("get.help"))
+}
 
-Usage:
-	goyuki get problem_no
+// get downloads the problem into dir
+func (c *GetCommand) get(p Provider, id, dir string, update bool) (*Info, error) {
+	_, err := os.Stat(dir)
+	exists := err == nil
+	if exists && !update {
+		return nil, newMsgError("get.exists", dir)
+	}
 
+	i, pf, err := c.fetch(p, id)
+	if err != nil {
+		return nil, err
+	}
 
-`
-	return strings.TrimSpace(helpText)
+	if !exists {
+		if err := os.MkdirAll(filepath.Dir(dir), DPerm); err != nil {
+			return nil, err
+		}
+		return i, save(dir, pf)
+	}
+	return i, c.update(dir, pf, !p.SamplesOnly())
 }
 
-func download(num int, cookie string) ([]byte, *Info, error) {
-	uri := strings.Join([]string{BaseURL, "no", fmt.Sprint(num)}, "/")
+// fetch returns the problem files from the site and keeps them in the cache.
+// The cached problem is used if offline or the download failed.
+func (c *GetCommand) fetch(p Provider, id string) (*Info, map[string][]byte, error) {
+	if c.Offline {
+		if c.Cache == nil {
+			return nil, nil, newMsgError("get.noCache")
+		}
+		return c.Cache.Load(p.Name(), id)
+	}
 
-	res, err := goreq.Request{
-		Uri:          uri,
-		MaxRedirects: 1,
-	}.Do()
+	defer forget(p, id)
+	i, pf, err := c.download(c.HTTP, p, id)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed problem request: %v", err)
+		if c.Cache == nil || !c.Cache.Exists(p.Name(), id) {
+			return nil, nil, err
+		}
+		c.UI.Warn(c.msg("get.restore", c.errMsg(err), id))
+		return c.Cache.Load(p.Name(), id)
 	}
 
-	if res.StatusCode == 404 {
-		return nil, nil, fmt.Errorf("the problem does not exist")
+	// sample cases don't replace the cached test cases
+	if c.Cache != nil && (!p.SamplesOnly() || !c.Cache.Exists(p.Name(), id)) {
+		if err := c.Cache.Store(p.Name(), id, pf, statement(p, id)); err != nil {
+			c.UI.Warn(c.msg("get.cacheFailed", id, c.errMsg(err)))
+		}
 	}
+	return i, pf, nil
+}
 
-	defer res.Body.Close()
-
-	i, err := parse(res.Body)
+// download downloads the problem files.
+// client is used for the images in the statement.
+// The archive entries which aren't test cases are skipped with a warning.
+func (m *Meta) download(client *HTTPClient, p Provider, id string) (*Info, map[string][]byte, error) {
+	i, err := p.Info(id)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	testCaseURI := strings.Join([]string{BaseURL, i.No, "testcase.zip"}, "/")
-	session := &http.Cookie{
-		Name:     "REVEL_SESSION",
-		Value:    cookie,
-		Path:     "/",
-		HttpOnly: true,
+	files, err := p.TestCases(id, i)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	res, err = goreq.Request{
-		Uri: testCaseURI,
-	}.WithCookie(session).Do()
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed testcase request: %v", err)
+	var rb []byte
+	if i.JudgeType > 0 {
+		rb, err = p.JudgeCode(id, i)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
-	defer res.Body.Close()
 
-	if res.StatusCode == 403 {
-		return nil, nil, fmt.Errorf("please log in to yukicoder")
+	now := time.Now()
+	i.Schema, i.FetchedAt, i.Checksum = InfoSchema, &now, TestSetChecksum(files)
+	i.Site = p.Name()
+
+	var sf map[string][]byte
+	if s, ok := p.(Statementer); ok {
+		i.URL = s.URL(id)
+		if page := s.Statement(id); page != nil {
+			sf, err = statementFiles(client, page, i.URL)
+			if err != nil {
+				return nil, nil, err
+			}
+			i.Tolerance = tolerance(string(sf[StatementMarkdown]))
+		}
 	}
 
-	buf := bytes.NewBuffer(make([]byte, 0, 1000000))
-	if _, err := io.Copy(buf, res.Body); err != nil {
+	pf, skipped, err := problemFiles(rb, i, files)
+	if err != nil {
 		return nil, nil, err
 	}
-	return buf.Bytes(), i, nil
+	if len(skipped) > 0 {
+		m.UI.Warn(m.msg("get.skipped", id, strings.Join(skipped, ", ")))
+	}
+	for name, b := range sf {
+		pf[name] = b
+	}
+	return i, pf, nil
 }
 
-func save(buf, rbuf []byte, i *Info, num int) error {
-	baseDir := fmt.Sprint(num)
-	if err := os.Mkdir(baseDir, DPerm); err != nil {
-		return err
+// statement returns the problem page if the provider keeps it
+func statement(p Provider, id string) []byte {
+	if s, ok := p.(Statementer); ok {
+		return s.Statement(id)
 	}
+	return nil
+}
 
-	b, err := json.Marshal(*i)
+// getContest downloads all problems of the contest with parallel workers
+func (c *GetCommand) getContest(y *Yukicoder, id int, alias bool, parallel int, update bool) int {
+	contest, err := y.API.Contest(id)
 	if err != nil {
-		return err
-	}
-
-	infoName := strings.Join([]string{baseDir, InfoFile}, "/")
-	if err := ioutil.WriteFile(infoName, b, FPerm); err != nil {
-		return err
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
 	}
 
-	if i.JudgeType > 0 {
-		codeName := strings.Join([]string{baseDir, ReactiveCode + Ext(i.RLang)}, "/")
-		if err := ioutil.WriteFile(codeName, rbuf, FPerm); err != nil {
-			return err
+	nums := make([]int, len(contest.ProblemIDList))
+	for n, pid := range contest.ProblemIDList {
+		p, err := y.API.ProblemByID(pid)
+		if err != nil {
+			c.UI.Error(c.msg("error.problemID", pid, c.errMsg(err)))
+			return ExitCodeFailed
 		}
+		nums[n] = p.No
 	}
 
-	zr, err := zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
-	if err != nil {
-		return err
+	baseDir := ""
+	if root := c.root(); root != "" {
+		baseDir = filepath.Join(root, y.Name())
 	}
+	if alias {
+		baseDir = filepath.Join(baseDir, fmt.Sprint(id))
+		if err := os.MkdirAll(baseDir, DPerm); err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+	}
+	c.UI.Output(c.msg("get.contest", contest.Name, len(nums)))
+
+	c.UI = &cli.ConcurrentUi{Ui: c.UI}
+	y.UI = c.UI
+	failed := runParallel(parallel, len(nums), func(n int) error {
+		num := nums[n]
+		dir := filepath.Join(baseDir, fmt.Sprint(num))
+		label := fmt.Sprintf("No.%d", num)
+		if alias {
+			label = contestAlias(n) + "\t" + label
+		}
 
-	var rc io.ReadCloser
-	for _, f := range zr.File {
-		err := func() error {
-			rc, err = f.Open()
-			if err != nil {
-				return err
-			}
-			defer rc.Close()
-
-			path := baseDir + "/" + f.Name
-			d := filepath.Dir(path)
-			if _, err = os.Stat(d); err != nil {
-				if err := os.Mkdir(d, DPerm); err != nil {
-					return err
-				}
-			}
-
-			output, err := os.Create(path)
-			if err != nil {
-				return err
-			}
-			defer output.Close()
-
-			if _, err := io.Copy(output, rc); err != nil {
-				return err
+		i, err := c.get(y, fmt.Sprint(num), dir, update)
+		if err == nil && alias {
+			link := filepath.Join(baseDir, contestAlias(n))
+			if _, lerr := os.Lstat(link); os.IsNotExist(lerr) {
+				err = os.Symlink(fmt.Sprint(num), link)
 			}
-			return nil
-		}()
+		}
 		if err != nil {
+			c.UI.Error(c.msg("error.downloadFailed", label, c.errMsg(err)))
 			return err
 		}
+		c.UI.Info(fmt.Sprintf("%s\t%s", label, i.Name))
+		return nil
+	})
+	c.UI.Output(c.msg("get.downloaded", len(nums)-failed, len(nums)))
+
+	if failed > 0 {
+		return ExitCodeFailed
 	}
-	return nil
+	return ExitCodeOK
 }
 
-func parse(r io.Reader) (*Info, error) {
-	i := &Info{}
-	doc, err := goquery.NewDocumentFromReader(r)
-	if err != nil {
-		return nil, fmt.Errorf("problem parse error: %v", err)
+// runParallel calls f(0), ..., f(n-1) with the workers and returns the number of errors
+func runParallel(workers, n int, f func(n int) error) int {
+	jobs := make(chan int)
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				errs[i] = f(i)
+			}
+		}()
 	}
 
-	content := doc.Find("div#content")
-	p := content.Find("p")
-
-	i.No, _ = content.Attr("data-problem-id")
-	i.Name = content.Find("h3").Text()
-	i.Level = p.First().Find("i.fa-star").Size()
-
-	if strings.Contains(content.Text(), "スペシャル") {
-		i.JudgeType = Special
+	for i := 0; i < n; i++ {
+		jobs <- i
 	}
+	close(jobs)
+	wg.Wait()
 
-	infoData := p.First().Text()
-
-	reg, _ := regexp.Compile(`[\d]+`)
-	match := reg.FindAllStringSubmatch(infoData, -1)
-
-	tm := [2]int{}
-	for i, v := range match[1:3] {
-		n, err := strconv.Atoi(v[0])
+	failed := 0
+	for _, err := range errs {
 		if err != nil {
-			return nil, err
+			failed++
 		}
-		tm[i] = n
 	}
-	i.Time, i.Mem = tm[0], tm[1]
+	return failed
+}
 
-	return i, nil
+// contestAlias returns the alias of n-th problem in the contest (A, B, ..., Z, AA, AB, ...)
+func contestAlias(n int) string {
+	if n < 26 {
+		return string(rune('A' + n))
+	}
+	return contestAlias(n/26-1) + contestAlias(n%26)
 }
 
-func downloadReactive(i *Info, cookie string) ([]byte, error) {
-	uri := strings.Join([]string{BaseURL, i.No, "code"}, "/")
-	session := &http.Cookie{
-		Name:     "REVEL_SESSION",
-		Value:    cookie,
-		Path:     "/",
-		HttpOnly: true,
+// problemFiles collects the files of the problem keyed by the slash-separated path
+// relative to the problem directory.
+// Only the test cases are taken from files so that an archive can't replace the other files.
+// It returns the names of the skipped entries in natural order.
+func problemFiles(rbuf []byte, i *Info, files map[string][]byte) (map[string][]byte, []string, error) {
+	pf := map[string][]byte{}
+	if i.JudgeType > 0 {
+		pf[ReactiveCode+Ext(i.RLang)] = rbuf
 	}
 
-	res, err := goreq.Request{
-		Uri:          uri,
-		MaxRedirects: 1,
-	}.WithCookie(session).Do()
-	if err != nil {
-		return nil, fmt.Errorf("failed problem request: %v", err)
+	var skipped []string
+	from := map[string]string{}
+	for name, b := range files {
+		c, ok := archiveCaseName(name)
+		if !ok {
+			skipped = append(skipped, name)
+			continue
+		}
+		if prev, ok := from[c]; ok {
+			return nil, nil, fmt.Errorf("duplicate testcase in archive: %s and %s", prev, name)
+		}
+		from[c] = name
+		pf[c] = b
 	}
-	defer res.Body.Close()
+	sort.Sort(naturalStrings(skipped))
 
-	buf, err := parseReactive(res.Body, i)
+	i.Files = nil
+	for name := range pf {
+		i.Files = append(i.Files, name)
+	}
+	sort.Sort(naturalStrings(i.Files))
+
+	b, err := json.Marshal(*i)
 	if err != nil {
-		return nil, fmt.Errorf("reactive code parse error: %v", err)
+		return nil, nil, err
 	}
-	return buf, nil
+	pf[InfoFile] = b
+	return pf, skipped, nil
 }
 
-func parseReactive(r io.Reader, i *Info) ([]byte, error) {
-	doc, err := goquery.NewDocumentFromReader(r)
-	if err != nil {
-		return nil, fmt.Errorf("judge code parse error: %v", err)
+// archiveCaseName maps the archive entry to the test case file by the last test_in or test_out in its path.
+// The directories between are joined to the file name with "_" (prefix/test_in/sub/1.txt is test_in/sub_1.txt).
+// It returns false if the entry isn't a test case.
+func archiveCaseName(name string) (string, bool) {
+	elems := strings.Split(name, "/")
+	for _, elem := range elems {
+		// metadata of the archiver
+		if elem == "__MACOSX" || strings.HasPrefix(elem, ".") {
+			return "", false
+		}
 	}
 
-	isReactive := false
-	doc.Find("option").EachWithBreak(func(n int, e *goquery.Selection) bool {
-		if _, ok := e.Attr("selected"); !ok {
-			return true
+	for n := len(elems) - 2; n >= 0; n-- {
+		if elems[n] == InputDir || elems[n] == OutputDir {
+			return elems[n] + "/" + strings.Join(elems[n+1:], "_"), true
 		}
+	}
+	return "", false
+}
 
-		lang := e.Text()
-		i.RLang, isReactive = lang[:strings.Index(lang, " ")], true
-		return false
-	})
+func save(baseDir string, files map[string][]byte) error {
+	if err := os.Mkdir(baseDir, DPerm); err != nil {
+		return err
+	}
 
-	if isReactive {
-		if i.JudgeType != Special {
-			i.JudgeType = Reactive
+	if err := writeFiles(baseDir, files); err != nil {
+		os.RemoveAll(baseDir)
+		return err
+	}
+	return nil
+}
+
+func writeFiles(baseDir string, files map[string][]byte) error {
+	paths := map[string]string{}
+	for name := range files {
+		p, err := safeJoin(baseDir, name)
+		if err != nil {
+			return err
 		}
-	} else {
-		i.JudgeType = Normal
+		paths[name] = p
 	}
 
-	buf := bytes.NewBuffer(make([]byte, 0, 1000000))
-	if _, err := buf.WriteString(doc.Find("textarea").Text()); err != nil {
-		return nil, err
+	for name, b := range files {
+		p := paths[name]
+		if err := os.MkdirAll(filepath.Dir(p), DPerm); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(p, b, FPerm); err != nil {
+			return err
+		}
 	}
-	return buf.Bytes(), nil
+	return nil
 }
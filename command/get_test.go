@@ -1,7 +1,17 @@
 package command
 
 import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/mitchellh/cli"
@@ -11,40 +21,136 @@ func TestGetCommand_implement(t *testing.T) {
 	var _ cli.Command = &GetCommand{}
 }
 
+// newSiteTestServer returns the stand-in of yukicoder problem pages.
+// No.1 to No.4 (problem id 17 to 20) have two sample cases and no judge code.
+// Their test case archive of cases (file name to content) requires the cookie "valid".
+func newSiteTestServer(cases map[string]string) *httptest.Server {
+	page := `<html><body><div id="content" data-problem-id="%d">
+<h3>No.%d</h3>
+<p><i class="fa fa-star"></i> 実行時間制限 : 1ケース 2秒 / メモリ制限 : 512 MB</p>
+<div class="sample"><h5>サンプル1</h5><div class="paragraph">
+<h6>入力</h6><pre>1 2
+</pre><h6>出力</h6><pre>3
+</pre></div></div>
+<div class="sample"><h5>サンプル2</h5><div class="paragraph">
+<h6>入力</h6><pre>3 4
+</pre><h6>出力</h6><pre>7
+</pre></div></div>
+</div></body></html>`
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var num int
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/no/"):
+			fmt.Sscan(strings.TrimPrefix(r.URL.Path, "/no/"), &num)
+			if num < 1 || num > 4 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprintf(w, page, num+16, num)
+		case strings.HasSuffix(r.URL.Path, "/code"):
+			fmt.Fprint(w, `<html><body><select><option>C++14 (gcc)</option></select><textarea></textarea></body></html>`)
+		case strings.HasSuffix(r.URL.Path, "/testcase.zip"):
+			if c, err := r.Cookie("REVEL_SESSION"); err != nil || c.Value != "valid" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			zw := zip.NewWriter(w)
+			for name, content := range cases {
+				f, err := zw.Create(name)
+				if err != nil {
+					return
+				}
+				io.WriteString(f, content)
+			}
+			zw.Close()
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
 func TestGetCommandUnSetEnv(t *testing.T) {
-	ui := new(cli.MockUi)
-	c := &GetCommand{
-		Meta: Meta{
-			UI: ui,
-		},
+	site := newSiteTestServer(nil)
+	defer site.Close()
+	api := newAPITestServer("")
+	defer api.Close()
+
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
 	}
+	defer os.RemoveAll(dir)
 
-	clearFunc := setEnv("GOYUKI", "")
+	clearFunc, err := tmpChdir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
 	defer clearFunc()
+	_, clearDirs, err := tmpUserDirs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clearDirs()
+	defer setEnv("GOYUKI", "")()
+	defer setEnv("GOYUKI_TOKEN", "")()
 
-	result := "$GOYUKI not set"
-	args := []string{"337"}
-	code := c.Run(args)
-	errs := ui.ErrorWriter.String()
+	ui := new(cli.MockUi)
+	c := &GetCommand{
+		Meta:  Meta{UI: ui},
+		Cache: &Cache{Dir: filepath.Join(dir, "cache")},
+		API:   &APIClient{URL: api.URL},
+		URL:   site.URL,
+	}
 
-	if code != ExitCodeFailed || !strings.Contains(errs, result) {
-		t.Errorf("bad status code = %v; want %v\nError message = %s; want %s", code, ExitCodeFailed, errs, result)
+	code := c.Run([]string{"1"})
+	if code != ExitCodeOK {
+		t.Fatalf("bad status code = %v; want %v\n%s", code, ExitCodeOK, ui.ErrorWriter.String())
+	}
+	if errs := ui.ErrorWriter.String(); !strings.Contains(errs, "$GOYUKIが設定されていません: サンプルケースのみダウンロードします") {
+		t.Errorf("warning = %s; want $GOYUKIが設定されていません", errs)
+	}
+
+	for name, want := range map[string]string{"sample_1.txt": "3\n", "sample_2.txt": "7\n"} {
+		if b, err := ioutil.ReadFile(filepath.Join("1", OutputDir, name)); err != nil || string(b) != want {
+			t.Errorf("%s = %q, %v; want %q", name, b, err, want)
+		}
+	}
+	if fis, _ := ioutil.ReadDir(filepath.Join("1", InputDir)); len(fis) != 2 {
+		t.Errorf("%d input files; want the 2 sample cases only", len(fis))
 	}
 }
 
 func TestGetCommandWrongCookie(t *testing.T) {
+	site := newSiteTestServer(nil)
+	defer site.Close()
+	api := newAPITestServer("")
+	defer api.Close()
+
+	dir, clearFunc, err := tmpUserDirs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clearFunc()
+	restore, err := tmpChdir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restore()
+	defer setEnv("GOYUKI", "test")()
+	defer setEnv("GOYUKI_TOKEN", "")()
+
 	ui := new(cli.MockUi)
 	c := &GetCommand{
 		Meta: Meta{
 			UI: ui,
 		},
+		API: &APIClient{URL: api.URL},
+		URL: site.URL,
 	}
 
-	clearFunc := setEnv("GOYUKI", "test")
-	defer clearFunc()
-
-	result := "please log in to yukicoder"
-	args := []string{"337"}
+	result := "yukicoderにログインしてください"
+	args := []string{"1"}
 	code := c.Run(args)
 	errs := ui.ErrorWriter.String()
 
@@ -54,17 +160,30 @@ func TestGetCommandWrongCookie(t *testing.T) {
 }
 
 func TestGetCommandWrongProblem(t *testing.T) {
+	site := newSiteTestServer(nil)
+	defer site.Close()
+
+	dir, clearFunc, err := tmpUserDirs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clearFunc()
+	restore, err := tmpChdir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restore()
+	defer setEnv("GOYUKI", "test")()
+
 	ui := new(cli.MockUi)
 	c := &GetCommand{
 		Meta: Meta{
 			UI: ui,
 		},
+		URL: site.URL,
 	}
 
-	clearFunc := setEnv("GOYUKI", "test")
-	defer clearFunc()
-
-	result := "the problem does not exist"
+	result := "問題が存在しません"
 	args := []string{"99999"}
 	code := c.Run(args)
 	errs := ui.ErrorWriter.String()
@@ -80,11 +199,16 @@ func TestGetCommandFlag(t *testing.T) {
 		code   int
 		result string
 	}{
-		{args: []string{"-l", "lang", "-hoge"}, code: ExitCodeFailed, result: "Invalid option"},
-		{args: []string{}, code: ExitCodeFailed, result: "Invalid arguments"},
-		{args: []string{"foobar"}, code: ExitCodeFailed, result: "invalid syntax"},
+		{args: []string{"-l", "lang", "-hoge"}, code: ExitCodeFailed, result: "不正なオプション"},
+		{args: []string{}, code: ExitCodeFailed, result: "不正な引数"},
+		{args: []string{"foobar"}, code: ExitCodeFailed, result: "対応していない問題"},
 	}
 
+	_, clearDirs, err := tmpUserDirs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clearDirs()
 	clearFunc := setEnv("GOYUKI", "test")
 	defer clearFunc()
 
@@ -104,3 +228,259 @@ func TestGetCommandFlag(t *testing.T) {
 		}
 	}
 }
+
+func TestParseSamples(t *testing.T) {
+	page := `<html><body><div id="content">
+<div class="sample"><h5>サンプル1</h5><div class="paragraph">
+<h6>入力</h6><pre>1 2
+</pre><h6>出力</h6><pre>3</pre></div></div>
+<div class="sample"><h5>サンプル2</h5><div class="paragraph">
+<h6>入力</h6><pre>
+10 20
+</pre><h6>出力</h6><pre>30
+</pre></div></div>
+</div></body></html>`
+
+	samples, err := parseSamples(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Sample{
+		{In: []byte("1 2\n"), Out: []byte("3\n")},
+		{In: []byte("10 20\n"), Out: []byte("30\n")},
+	}
+	if len(samples) != len(want) {
+		t.Fatalf("len(samples) = %d; want %d", len(samples), len(want))
+	}
+	for n, sample := range samples {
+		if string(sample.In) != string(want[n].In) || string(sample.Out) != string(want[n].Out) {
+			t.Errorf("samples[%d] = %q, %q; want %q, %q", n, sample.In, sample.Out, want[n].In, want[n].Out)
+		}
+	}
+
+	if _, err := parseSamples(strings.NewReader("<html></html>")); err == nil {
+		t.Error("parseSamples(no sample) = nil; want error")
+	}
+}
+
+func TestProblemFiles(t *testing.T) {
+	i := &Info{No: "1", JudgeType: Reactive, RLang: "C++11"}
+	files := map[string][]byte{
+		"test_in/1.txt":          []byte("1"),
+		"test_out/1.txt":         []byte("2"),
+		InfoFile:                 []byte("{}"),
+		ReactiveCode + ".cpp":    []byte("int main(){}"),
+		ProblemConfigFile:        []byte("[run]"),
+		"test_in/sub/2.txt":      []byte("3"),
+		"statement/statement.md": []byte("# 1"),
+	}
+
+	pf, skipped, err := problemFiles([]byte("reactive"), i, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"test_in/1.txt", "test_out/1.txt", "test_in/sub_2.txt", InfoFile, ReactiveCode + ".cpp"}
+	if len(pf) != len(want) {
+		t.Errorf("problemFiles() has %d files; want %d", len(pf), len(want))
+	}
+	for _, name := range want {
+		if _, ok := pf[name]; !ok {
+			t.Errorf("problemFiles() doesn't have %s", name)
+		}
+	}
+	if string(pf[ReactiveCode+".cpp"]) != "reactive" {
+		t.Errorf("%s = %q; want %q", ReactiveCode+".cpp", pf[ReactiveCode+".cpp"], "reactive")
+	}
+	if string(pf[InfoFile]) == "{}" {
+		t.Errorf("%s is replaced by the archive", InfoFile)
+	}
+	if wantSkipped := []string{ProblemConfigFile, InfoFile, ReactiveCode + ".cpp", "statement/statement.md"}; !reflect.DeepEqual(skipped, wantSkipped) {
+		t.Errorf("problemFiles() skipped %v; want %v", skipped, wantSkipped)
+	}
+
+	dup := map[string][]byte{"a/test_in/1.txt": []byte("1"), "b/test_in/1.txt": []byte("1")}
+	if _, _, err := problemFiles(nil, &Info{}, dup); err == nil {
+		t.Error("problemFiles(duplicate cases) = nil; want error")
+	}
+}
+
+func TestArchiveCaseName(t *testing.T) {
+	cases := []struct {
+		name string
+		want string
+		ok   bool
+	}{
+		{name: "test_in/1.txt", want: "test_in/1.txt", ok: true},
+		{name: "prefix/test_in/1.txt", want: "test_in/1.txt", ok: true},
+		{name: "a/b/test_out/1.txt", want: "test_out/1.txt", ok: true},
+		{name: "test_in/sub/1.txt", want: "test_in/sub_1.txt", ok: true},
+		{name: "test_in/test_out/1.txt", want: "test_out/1.txt", ok: true},
+		{name: "test_in", ok: false},
+		{name: "prefix/readme.txt", ok: false},
+		{name: "__MACOSX/test_in/._1.txt", ok: false},
+		{name: "test_in/.DS_Store", ok: false},
+	}
+
+	for _, testCase := range cases {
+		got, ok := archiveCaseName(testCase.name)
+		if got != testCase.want || ok != testCase.ok {
+			t.Errorf("archiveCaseName(%q) = %q, %v; want %q, %v", testCase.name, got, ok, testCase.want, testCase.ok)
+		}
+	}
+}
+
+func TestGetCommandNestedArchive(t *testing.T) {
+	dir, clearDirs, err := tmpUserDirs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clearDirs()
+	restore, err := tmpChdir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restore()
+	defer setEnv("GOYUKI", "valid")()
+	defer setEnv("GOYUKI_TOKEN", "")()
+
+	site := newSiteTestServer(map[string]string{
+		"prefix/test_in/1.txt":  "1 2",
+		"prefix/test_out/1.txt": "3",
+		"prefix/readme.txt":     "readme",
+	})
+	defer site.Close()
+	api := newAPITestServer("")
+	defer api.Close()
+
+	ui := cli.NewMockUi()
+	c := &GetCommand{Meta: Meta{UI: ui}, API: &APIClient{URL: api.URL}, URL: site.URL}
+	if code := c.Run([]string{"1"}); code != ExitCodeOK {
+		t.Fatalf("bad status code = %v; want %v\n%s", code, ExitCodeOK, ui.ErrorWriter.String())
+	}
+
+	for name, want := range map[string]string{"test_in/1.txt": "1 2", "test_out/1.txt": "3"} {
+		if b, err := ioutil.ReadFile(filepath.Join("1", name)); err != nil || string(b) != want {
+			t.Errorf("%s = %q, %v; want %q", name, b, err, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join("1", "prefix")); !os.IsNotExist(err) {
+		t.Errorf("prefix directory exists")
+	}
+	if errs := ui.ErrorWriter.String(); !strings.Contains(errs, "prefix/readme.txt") {
+		t.Errorf("warning = %s; want prefix/readme.txt", errs)
+	}
+}
+
+func TestGetCommandFetchForget(t *testing.T) {
+	site := newSiteTestServer(nil)
+	defer site.Close()
+
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ui := new(cli.MockUi)
+	c := &GetCommand{Meta: Meta{UI: ui}, Cache: &Cache{Dir: dir}}
+	y := &Yukicoder{UI: ui, API: &APIClient{}, Samples: true, Base: site.URL}
+	if _, _, err := c.fetch(y, "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(y.pages) != 0 || len(y.codes) != 0 {
+		t.Errorf("%d pages and %d codes are kept after fetch; want 0", len(y.pages), len(y.codes))
+	}
+	if b, err := c.Cache.Statement(y.Name(), "1"); err != nil || len(b) == 0 {
+		t.Errorf("cached statement = %q, %v; want the problem page", b, err)
+	}
+}
+
+func TestRunParallel(t *testing.T) {
+	var mu sync.Mutex
+	done := map[int]bool{}
+	failed := runParallel(3, 10, func(n int) error {
+		mu.Lock()
+		done[n] = true
+		mu.Unlock()
+		if n%4 == 0 {
+			return fmt.Errorf("job %d", n)
+		}
+		return nil
+	})
+
+	if failed != 3 {
+		t.Errorf("runParallel() = %d; want 3", failed)
+	}
+	if len(done) != 10 {
+		t.Errorf("%d jobs done; want 10", len(done))
+	}
+}
+
+func TestGetCommandContest(t *testing.T) {
+	site := newSiteTestServer(nil)
+	defer site.Close()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var id int
+		switch {
+		case r.URL.Path == "/contest/id/5":
+			fmt.Fprint(w, `{"Id":5,"Name":"contest","ProblemIdList":[17,18,19,20]}`)
+		case strings.HasPrefix(r.URL.Path, "/problems/") && !strings.HasPrefix(r.URL.Path, "/problems/no/"):
+			fmt.Sscan(strings.TrimPrefix(r.URL.Path, "/problems/"), &id)
+			fmt.Fprintf(w, `{"No":%d,"ProblemId":%d}`, id-16, id)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer api.Close()
+
+	dir, clearFunc, err := tmpUserDirs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clearFunc()
+	restore, err := tmpChdir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restore()
+	defer setEnv("GOYUKI", "")()
+	defer setEnv("GOYUKI_TOKEN", "")()
+
+	ui := new(cli.MockUi)
+	c := &GetCommand{
+		Meta: Meta{UI: ui},
+		API:  &APIClient{URL: api.URL},
+		URL:  site.URL,
+	}
+	if code := c.Run([]string{"-s", "-a", "-j", "4", "-c", "5"}); code != ExitCodeOK {
+		t.Fatalf("bad status code = %v; want %v\n%s", code, ExitCodeOK, ui.ErrorWriter.String())
+	}
+
+	for n, alias := range []string{"A", "B", "C", "D"} {
+		p := filepath.Join("5", alias, OutputDir, "sample_2.txt")
+		if b, err := ioutil.ReadFile(p); err != nil || string(b) != "7\n" {
+			t.Errorf("No.%d: %s = %q, %v", n+1, p, b, err)
+		}
+	}
+	if out := ui.OutputWriter.String(); !strings.Contains(out, "4/4問をダウンロードしました") {
+		t.Errorf("output = %s", out)
+	}
+}
+
+func TestContestAlias(t *testing.T) {
+	testCases := []struct {
+		n     int
+		alias string
+	}{
+		{0, "A"}, {1, "B"}, {25, "Z"}, {26, "AA"}, {27, "AB"}, {52, "BA"},
+	}
+
+	for _, testCase := range testCases {
+		if alias := contestAlias(testCase.n); alias != testCase.alias {
+			t.Errorf("contestAlias(%d) = %s; want %s", testCase.n, alias, testCase.alias)
+		}
+	}
+}
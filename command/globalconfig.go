@@ -0,0 +1,217 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ConfigFile is the global config file in the config directory
+const ConfigFile = "config.toml"
+
+// Config is the global config with the defaults of the commands
+type Config struct {
+	// Language is the preferred language name of Lang
+	Language  string `toml:"language,omitempty"`
+	Validater string `toml:"validater,omitempty"`
+	// Color is auto, always or never
+	Color    string `toml:"color,omitempty"`
+	Parallel int    `toml:"parallel,omitzero"`
+	// Root is the workspace root to save the problems in (root/site/id)
+	Root string `toml:"root,omitempty"`
+	// Format is the output format of the test results (text or json)
+	Format string `toml:"format,omitempty"`
+	// Locale is the language of the messages (ja or en)
+	Locale string `toml:"locale,omitempty"`
+}
+
+// DefaultConfig is used for the keys not set in the config file
+var DefaultConfig = Config{
+	Validater: "diff",
+	Color:     "auto",
+	Parallel:  4,
+	Format:    "text",
+}
+
+// ConfigPath returns the path of the config file
+func ConfigPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, ConfigFile), nil
+}
+
+// ReadConfig reads the config file as is.
+// An empty config is returned if the file does not exist.
+func ReadConfig(p string) (*Config, error) {
+	conf := &Config{}
+	if _, err := os.Stat(p); os.IsNotExist(err) {
+		return conf, nil
+	}
+
+	md, err := toml.DecodeFile(p, conf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", ConfigFile, err)
+	}
+	if keys := md.Undecoded(); len(keys) > 0 {
+		return nil, fmt.Errorf("unknown key in %s: %s", ConfigFile, keys[0])
+	}
+	if err := conf.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", ConfigFile, err)
+	}
+	return conf, nil
+}
+
+// LoadConfig reads the config file and fills the keys not set with DefaultConfig
+func LoadConfig() (*Config, error) {
+	p, err := ConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	conf, err := ReadConfig(p)
+	if err != nil {
+		return nil, err
+	}
+	return conf.withDefaults(), nil
+}
+
+// Write writes the config into the file p
+func (c *Config) Write(p string) error {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(c); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p), DPerm); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p, buf.Bytes(), FPerm)
+}
+
+// ConfigKeys returns the keys of the config in the definition order
+func ConfigKeys() []string {
+	var keys []string
+	t := reflect.TypeOf(Config{})
+	for n := 0; n < t.NumField(); n++ {
+		keys = append(keys, configKey(t.Field(n)))
+	}
+	return keys
+}
+
+// Get returns the value of the key
+func (c *Config) Get(key string) (string, error) {
+	v, err := c.field(key)
+	if err != nil {
+		return "", err
+	}
+
+	if v.Kind() == reflect.Int {
+		return strconv.Itoa(int(v.Int())), nil
+	}
+	return v.String(), nil
+}
+
+// Set sets the value of the key.
+// The empty value resets the key to the default.
+func (c *Config) Set(key, value string) error {
+	v, err := c.field(key)
+	if err != nil {
+		return err
+	}
+
+	old := *c
+	if v.Kind() == reflect.Int {
+		n := 0
+		if value != "" {
+			if n, err = strconv.Atoi(value); err != nil {
+				return newMsgError("config.invalidValue", key, value)
+			}
+		}
+		v.SetInt(int64(n))
+	} else {
+		v.SetString(value)
+	}
+
+	if err := c.validate(); err != nil {
+		*c = old
+		return err
+	}
+	return nil
+}
+
+func (c *Config) field(key string) (reflect.Value, error) {
+	v := reflect.ValueOf(c).Elem()
+	for n := 0; n < v.NumField(); n++ {
+		if configKey(v.Type().Field(n)) == key {
+			return v.Field(n), nil
+		}
+	}
+	return reflect.Value{}, newMsgError("config.invalidKey", key)
+}
+
+func configKey(f reflect.StructField) string {
+	return strings.Split(f.Tag.Get("toml"), ",")[0]
+}
+
+// validate checks the keys which are set
+func (c *Config) validate() error {
+	if _, ok := Lang[c.Language]; c.Language != "" && !ok {
+		return newMsgError("error.invalidLanguage", c.Language)
+	}
+	if _, ok := Validaters[c.Validater]; c.Validater != "" && !ok {
+		return newMsgError("error.invalidValidater", c.Validater)
+	}
+
+	checks := []struct {
+		key, value string
+		values     []string
+	}{
+		{"color", c.Color, []string{"auto", "always", "never"}},
+		{"format", c.Format, []string{"text", "json"}},
+		{"locale", c.Locale, []string{"ja", "en"}},
+	}
+	for _, check := range checks {
+		if check.value != "" && !contains(check.values, check.value) {
+			return newMsgError("config.invalidChoice", check.key, check.value, strings.Join(check.values, ", "))
+		}
+	}
+
+	if c.Parallel < 0 {
+		return newMsgError("error.invalidParallel", c.Parallel)
+	}
+	return nil
+}
+
+// withDefaults returns the copy of the config filled with DefaultConfig
+func (c *Config) withDefaults() *Config {
+	conf := *c
+	v, d := reflect.ValueOf(&conf).Elem(), reflect.ValueOf(DefaultConfig)
+	for n := 0; n < v.NumField(); n++ {
+		if isZero(v.Field(n)) {
+			v.Field(n).Set(d.Field(n))
+		}
+	}
+	return &conf
+}
+
+func isZero(v reflect.Value) bool {
+	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+}
+
+func contains(strs []string, s string) bool {
+	for _, str := range strs {
+		if str == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	testCases := []struct {
+		config string
+		result *Config
+		err    string
+	}{
+		{config: "language = \"cpp\"\nparallel = 8\n", result: &Config{Language: "cpp", Parallel: 8}},
+		{config: "color = \"never\"\nformat = \"json\"\nlocale = \"en\"\n", result: &Config{Color: "never", Format: "json", Locale: "en"}},
+		{config: "language = \"foo\"\n", err: "不正な言語"},
+		{config: "color = \"yes\"\n", err: "不正なcolor"},
+		{config: "parallel = -1\n", err: "不正な並列数"},
+		{config: "foo = 1\n", err: "unknown key"},
+		{config: "language = ", err: "failed to read"},
+	}
+
+	p := filepath.Join(dir, ConfigFile)
+	for _, testCase := range testCases {
+		if err := ioutil.WriteFile(p, []byte(testCase.config), FPerm); err != nil {
+			t.Fatal(err)
+		}
+
+		conf, err := ReadConfig(p)
+		if testCase.err != "" {
+			if err == nil || !strings.Contains(err.Error(), testCase.err) {
+				t.Errorf("ReadConfig(%q) error = %v; want %s", testCase.config, err, testCase.err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(conf, testCase.result) {
+			t.Errorf("ReadConfig(%q) = %+v, %v; want %+v", testCase.config, conf, err, testCase.result)
+		}
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir, clear, err := tmpConfigDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clear()
+
+	conf, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*conf, DefaultConfig) {
+		t.Errorf("LoadConfig = %+v; want %+v", conf, DefaultConfig)
+	}
+
+	p := filepath.Join(dir, "goyuki", ConfigFile)
+	if err := (&Config{Language: "go", Parallel: 2}).Write(p); err != nil {
+		t.Fatal(err)
+	}
+
+	conf, err = LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := DefaultConfig
+	want.Language, want.Parallel = "go", 2
+	if !reflect.DeepEqual(*conf, want) {
+		t.Errorf("LoadConfig = %+v; want %+v", conf, want)
+	}
+}
+
+func TestConfigSet(t *testing.T) {
+	testCases := []struct {
+		key   string
+		value string
+		err   string
+	}{
+		{key: "language", value: "cpp"},
+		{key: "parallel", value: "2"},
+		{key: "root", value: "/tmp/yukicoder"},
+		{key: "language", value: ""},
+		{key: "parallel", value: "two", err: "不正なparallel"},
+		{key: "format", value: "xml", err: "不正なformat"},
+		{key: "validater", value: "foo", err: "不正なバリデータ"},
+		{key: "foo", value: "bar", err: "不正なキー"},
+	}
+
+	conf := &Config{}
+	for _, testCase := range testCases {
+		old := *conf
+		err := conf.Set(testCase.key, testCase.value)
+		if testCase.err != "" {
+			if err == nil || !strings.Contains(err.Error(), testCase.err) {
+				t.Errorf("Set(%s, %s) error = %v; want %s", testCase.key, testCase.value, err, testCase.err)
+			}
+			if !reflect.DeepEqual(*conf, old) {
+				t.Errorf("Set(%s, %s) changed the config: %+v", testCase.key, testCase.value, conf)
+			}
+			continue
+		}
+
+		v, err := conf.Get(testCase.key)
+		if err != nil || v != testCase.value {
+			t.Errorf("Get(%s) = %s, %v; want %s", testCase.key, v, err, testCase.value)
+		}
+	}
+}
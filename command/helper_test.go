@@ -1,6 +1,22 @@
 package command
 
-import "os"
+import (
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+func init() {
+	// retry failed requests without waiting in tests
+	DefaultBackoff, DefaultInterval = time.Millisecond, 0
+	DefaultHTTPClient.Backoff = time.Millisecond
+	DefaultHTTPClient.Interval = 0
+
+	// messages are checked in Japanese
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		os.Unsetenv(env)
+	}
+}
 
 // setEnv set enviromental variables and return restore function.
 func setEnv(key, val string) func() {
@@ -27,3 +43,36 @@ func tmpChdir(dir string) (func(), error) {
 		os.Chdir(currentDir)
 	}, nil
 }
+
+func tmpConfigDir() (string, func(), error) {
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		return "", nil, err
+	}
+
+	clearFunc := setEnv("XDG_CONFIG_HOME", dir)
+	return dir, func() {
+		clearFunc()
+		os.RemoveAll(dir)
+	}, nil
+}
+
+// tmpUserDirs points the config, cache and data directories to a new temporary directory
+// so that the tests don't read the saved credentials or write the cache of the user.
+func tmpUserDirs() (string, func(), error) {
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		return "", nil, err
+	}
+
+	var clearFuncs []func()
+	for _, env := range []string{"XDG_CONFIG_HOME", "XDG_CACHE_HOME", "XDG_DATA_HOME"} {
+		clearFuncs = append(clearFuncs, setEnv(env, dir))
+	}
+	return dir, func() {
+		for _, f := range clearFuncs {
+			f()
+		}
+		os.RemoveAll(dir)
+	}, nil
+}
@@ -0,0 +1,170 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// HistoryCommand is a Command that shows the runs recorded by run
+type HistoryCommand struct {
+	Meta
+
+	// Path is used instead of HistoryPath if it is not empty
+	Path string
+}
+
+// Run lists the runs or shows a run
+func (c *HistoryCommand) Run(args []string) int {
+	var (
+		showFlag   uint64
+		sourceFlag uint64
+	)
+
+	flags := c.Meta.NewFlagSet("history", c.Help())
+	flags.Uint64Var(&showFlag, "show", 0, "show the case results of the run")
+	flags.Uint64Var(&showFlag, "s", 0, "show the case results of the run")
+	flags.Uint64Var(&sourceFlag, "source", 0, "print the source code of the run")
+
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(c.msg("error.invalidOption", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+	args = flags.Args()
+
+	if len(args) > 1 || (len(args) == 1 && (showFlag > 0 || sourceFlag > 0)) {
+		c.UI.Error(c.msg("error.invalidArguments", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+
+	p := c.Path
+	if p == "" {
+		var err error
+		if p, err = HistoryPath(); err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+	}
+	if _, err := os.Stat(p); os.IsNotExist(err) {
+		c.UI.Output(c.msg("history.empty"))
+		return ExitCodeOK
+	}
+
+	h, err := OpenHistory(p)
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+	defer h.Close()
+
+	switch {
+	case showFlag > 0:
+		r, err := h.Get(showFlag)
+		if err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+		c.UI.Output(historyLine(r))
+		for _, cr := range r.Cases {
+			c.UI.Output(caseLine(cr))
+		}
+		return ExitCodeOK
+	case sourceFlag > 0:
+		r, err := h.Get(sourceFlag)
+		if err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+		c.UI.Output(strings.TrimRight(r.Code, "\n"))
+		return ExitCodeOK
+	}
+
+	site, problem := "", ""
+	if len(args) == 1 {
+		if site, problem, err = c.problem(args[0]); err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+	}
+
+	runs, err := h.Runs(site, problem)
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+	if len(runs) == 0 {
+		c.UI.Output(c.msg("history.empty"))
+		return ExitCodeOK
+	}
+
+	for _, r := range runs {
+		c.UI.Output(historyLine(r))
+	}
+	if problem != "" {
+		c.UI.Output(c.progression(runs))
+	}
+	return ExitCodeOK
+}
+
+// problem returns the site and the problem id of the problem_no
+// (taken from info.json if the problem is downloaded, otherwise from the providers)
+func (c *HistoryCommand) problem(spec string) (string, string, error) {
+	dir, err := ProblemDir(c.root(), spec)
+	if err != nil {
+		return "", "", err
+	}
+
+	if info, err := ReadInfo(dir); err == nil && info.No != "" {
+		site, id := info.ProblemID()
+		return site, id, nil
+	}
+
+	providers, err := Providers()
+	if err != nil {
+		return "", "", err
+	}
+	p, id, err := FindProvider(providers, spec)
+	if err != nil {
+		return "", "", err
+	}
+	return p.Name(), id, nil
+}
+
+// progression returns how the verdict of the problem changed and when it was accepted first
+func (c *HistoryCommand) progression(runs []*RunRecord) string {
+	verdicts := make([]string, len(runs))
+	for n, r := range runs {
+		verdicts[n] = r.Verdict()
+	}
+	line := c.msg("history.progress", strings.Join(verdicts, " -> "))
+
+	for n, r := range runs {
+		if r.Verdict() == "AC" {
+			return line + "\n" + c.msg("history.firstAC", r.ID, r.Date.Format("2006-01-02 15:04"), n+1)
+		}
+	}
+	return line + "\n" + c.msg("history.noAC", len(runs))
+}
+
+// historyLine returns the summary of the run
+// (#id date problem language verdict accepted/cases time memory)
+func historyLine(r *RunRecord) string {
+	return fmt.Sprintf("#%d\t%s\t%s\t%s\t%s\t%d/%d\t%d ms\t%d KB",
+		r.ID, r.Date.Format("2006-01-02 15:04"), r.Label(), r.Language,
+		Verdict(r.Verdict()), r.Accepted(), len(r.Cases), r.MaxTime(), r.MaxMemory())
+}
+
+// caseLine returns the result of the case
+func caseLine(r *CaseResult) string {
+	return fmt.Sprintf("%s\t%d ms\t%d KB\t%s", Verdict(r.Verdict), r.Time, r.Memory, r.Name)
+}
+
+// Synopsis is a one-line, short synopsis of the command.
+func (c *HistoryCommand) Synopsis() string {
+	return c.msg("history.synopsis")
+}
+
+// Help is a long-form help text
+func (c *HistoryCommand) Help() string {
+	return strings.TrimSpace(c.msg("history.help"))
+}
@@ -0,0 +1,115 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestHistoryCommand_implement(t *testing.T) {
+	var _ cli.Command = &HistoryCommand{}
+}
+
+func TestHistoryCommand(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	_, clearDirs, err := tmpUserDirs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clearDirs()
+
+	p := filepath.Join(dir, HistoryFile)
+	h, err := OpenHistory(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := []*RunRecord{
+		NewRunRecord(&Info{No: "1010", Number: 10}, "Go", "main.go", []byte("package main\n"), []*CaseResult{{Name: "1.txt", Verdict: "WA", Time: 3}}),
+		NewRunRecord(&Info{No: "1020", Number: 20}, "Go", "main.go", []byte("b"), []*CaseResult{{Name: "1.txt", Verdict: "AC"}}),
+		NewRunRecord(&Info{No: "1010", Number: 10}, "Go", "main.go", []byte("c"), []*CaseResult{{Name: "1.txt", Verdict: "AC", Memory: 2048}}),
+		NewRunRecord(&Info{No: "abc001_a", Site: "atcoder"}, "Go", "main.go", []byte("d"), []*CaseResult{{Name: "1.txt", Verdict: "AC"}}),
+	}
+	for _, r := range records {
+		if err := h.Add(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	h.Close()
+
+	testCases := []struct {
+		args   []string
+		code   int
+		result []string
+	}{
+		{args: []string{"-foo"}, code: ExitCodeFailed, result: []string{"不正なオプション"}},
+		{args: []string{"1", "2"}, code: ExitCodeFailed, result: []string{"不正な引数"}},
+		{args: []string{"-show", "1", "10"}, code: ExitCodeFailed, result: []string{"不正な引数"}},
+		{args: []string{"-show", "9"}, code: ExitCodeFailed, result: []string{"実行が見つかりません: 9"}},
+		{args: []string{}, code: ExitCodeOK, result: []string{"#1\t", "#2\t", "#3\t", "\tNo.10\t", "\tabc001_a\t"}},
+		{args: []string{"10"}, code: ExitCodeOK, result: []string{"#1\t", "#3\t", "WA -> AC", "#3 (", "2回目"}},
+		{args: []string{"1010"}, code: ExitCodeOK, result: []string{"履歴がありません"}},
+		{args: []string{"abc001_a"}, code: ExitCodeOK, result: []string{"#4\t", "abc001_a"}},
+		{args: []string{"20"}, code: ExitCodeOK, result: []string{"#2\t", "AC"}},
+		{args: []string{"30"}, code: ExitCodeOK, result: []string{"履歴がありません"}},
+		{args: []string{"-s", "3"}, code: ExitCodeOK, result: []string{"#3\t", "2048 KB\t1.txt"}},
+		{args: []string{"-source", "1"}, code: ExitCodeOK, result: []string{"package main"}},
+	}
+
+	for _, testCase := range testCases {
+		ui := new(cli.MockUi)
+		c := &HistoryCommand{Meta: Meta{UI: ui}, Path: p}
+
+		code := c.Run(testCase.args)
+		out := ui.ErrorWriter.String()
+		if code == ExitCodeOK {
+			out = ui.OutputWriter.String()
+		}
+		if code != testCase.code {
+			t.Errorf("Run(%v) = %d; want %d\n%s", testCase.args, code, testCase.code, out)
+		}
+		for _, result := range testCase.result {
+			if !strings.Contains(out, result) {
+				t.Errorf("Run(%v) output = %s; want %q", testCase.args, out, result)
+			}
+		}
+	}
+
+	ui := new(cli.MockUi)
+	c := &HistoryCommand{Meta: Meta{UI: ui}, Path: p}
+	c.Run([]string{"20"})
+	if out := ui.OutputWriter.String(); strings.Contains(out, "#1\t") || strings.Contains(out, "まだ") {
+		t.Errorf("Run(20) output = %s; want run 2 only", out)
+	}
+
+	// the downloaded problem is looked up by the problem number, not by No
+	problemDir := filepath.Join(dir, "problem")
+	if err := os.Mkdir(problemDir, DPerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(problemDir, InfoFile), []byte(`{"No":"1010","Number":10}`), FPerm); err != nil {
+		t.Fatal(err)
+	}
+	ui = new(cli.MockUi)
+	c = &HistoryCommand{Meta: Meta{UI: ui}, Path: p}
+	c.Run([]string{problemDir})
+	if out := ui.OutputWriter.String(); !strings.Contains(out, "#1\t") || !strings.Contains(out, "#3\t") || strings.Contains(out, "#2\t") {
+		t.Errorf("Run(%s) output = %s; want runs 1 and 3", problemDir, out)
+	}
+
+	ui = new(cli.MockUi)
+	c = &HistoryCommand{Meta: Meta{UI: ui}, Path: filepath.Join(dir, "none.db")}
+	if code := c.Run(nil); code != ExitCodeOK || !strings.Contains(ui.OutputWriter.String(), "履歴がありません") {
+		t.Errorf("Run() without the history = %d, %s", code, ui.OutputWriter.String())
+	}
+	if _, err := os.Stat(filepath.Join(dir, "none.db")); !os.IsNotExist(err) {
+		t.Errorf("history is created by history command")
+	}
+}
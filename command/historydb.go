@@ -0,0 +1,230 @@
+package command
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// HistoryFile is the run history database in DataDir
+const HistoryFile = "history.db"
+
+var runsBucket = []byte("runs")
+
+// DataDir returns goyuki data directory ($XDG_DATA_HOME/goyuki or ~/.local/share/goyuki)
+func DataDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "goyuki"), nil
+	}
+
+	home := os.Getenv("HOME")
+	if home == "" {
+		return "", fmt.Errorf("$HOME not set")
+	}
+	return filepath.Join(home, ".local", "share", "goyuki"), nil
+}
+
+// HistoryPath returns the path of the run history database
+func HistoryPath() (string, error) {
+	dir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, HistoryFile), nil
+}
+
+// RunRecord is a run of the tests recorded in the history
+type RunRecord struct {
+	ID   uint64    `json:"id"`
+	Date time.Time `json:"date"`
+	// Site and Problem are the provider and the problem id it accepts (see Info.ProblemID)
+	Site     string `json:"site"`
+	Problem  string `json:"problem"`
+	Number   int    `json:"number,omitempty"`
+	Name     string `json:"name"`
+	Language string `json:"language"`
+	Source   string `json:"source"`
+	// SourceHash is the sha256 of Code
+	SourceHash string        `json:"source_hash"`
+	Code       string        `json:"code"`
+	Cases      []*CaseResult `json:"cases"`
+}
+
+// NewRunRecord returns the record of the source code and the case results
+func NewRunRecord(info *Info, lang, source string, code []byte, cases []*CaseResult) *RunRecord {
+	sum := sha256.Sum256(code)
+	site, problem := info.ProblemID()
+	return &RunRecord{
+		Date:       time.Now(),
+		Site:       site,
+		Problem:    problem,
+		Number:     info.Number,
+		Name:       info.Name,
+		Language:   lang,
+		Source:     filepath.Base(source),
+		SourceHash: hex.EncodeToString(sum[:]),
+		Code:       string(code),
+		Cases:      cases,
+	}
+}
+
+// Verdict returns AC if all cases are accepted, otherwise the first verdict which is not AC
+func (r *RunRecord) Verdict() string {
+	for _, c := range r.Cases {
+		if c.Verdict != "AC" {
+			return c.Verdict
+		}
+	}
+	if len(r.Cases) == 0 {
+		return "-"
+	}
+	return "AC"
+}
+
+// Accepted returns the number of the accepted cases
+func (r *RunRecord) Accepted() int {
+	n := 0
+	for _, c := range r.Cases {
+		if c.Verdict == "AC" {
+			n++
+		}
+	}
+	return n
+}
+
+// Label returns the problem as shown on the site (No.number on yukicoder)
+func (r *RunRecord) Label() string {
+	if r.Number > 0 {
+		return fmt.Sprintf("No.%d", r.Number)
+	}
+	return r.Problem
+}
+
+// MaxTime returns the longest execution time of the cases in ms
+func (r *RunRecord) MaxTime() int64 {
+	var t int64
+	for _, c := range r.Cases {
+		if c.Time > t {
+			t = c.Time
+		}
+	}
+	return t
+}
+
+// MaxMemory returns the largest memory usage of the cases in KB
+func (r *RunRecord) MaxMemory() int64 {
+	var m int64
+	for _, c := range r.Cases {
+		if c.Memory > m {
+			m = c.Memory
+		}
+	}
+	return m
+}
+
+// History is the database of the runs
+type History struct {
+	db *bolt.DB
+}
+
+// OpenHistory opens the history database in p, creating it if it does not exist
+func OpenHistory(p string) (*History, error) {
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return nil, err
+	}
+
+	db, err := bolt.Open(p, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("history: %v", err)
+	}
+	return &History{db: db}, nil
+}
+
+// Close closes the database
+func (h *History) Close() error {
+	return h.db.Close()
+}
+
+// Add records r and sets its ID
+func (h *History) Add(r *RunRecord) error {
+	return h.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(runsBucket)
+		if err != nil {
+			return err
+		}
+
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		r.ID = id
+
+		v, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		return b.Put(runKey(id), v)
+	})
+}
+
+// Get returns the run of the id
+func (h *History) Get(id uint64) (*RunRecord, error) {
+	var r *RunRecord
+	err := h.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(runsBucket)
+		if b == nil {
+			return nil
+		}
+
+		v := b.Get(runKey(id))
+		if v == nil {
+			return nil
+		}
+		r = &RunRecord{}
+		return json.Unmarshal(v, r)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, newMsgError("history.notFound", id)
+	}
+	return r, nil
+}
+
+// Runs returns the runs of the problem of the site in the recorded order (all runs if problem is empty)
+func (h *History) Runs(site, problem string) ([]*RunRecord, error) {
+	var runs []*RunRecord
+	err := h.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(runsBucket)
+		if b == nil {
+			return nil
+		}
+
+		return b.ForEach(func(k, v []byte) error {
+			r := &RunRecord{}
+			if err := json.Unmarshal(v, r); err != nil {
+				return err
+			}
+			if problem == "" || (r.Site == site && r.Problem == problem) {
+				runs = append(runs, r)
+			}
+			return nil
+		})
+	})
+	return runs, err
+}
+
+// runKey returns the key of the id which sorts in the recorded order
+func runKey(id uint64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, id)
+	return k
+}
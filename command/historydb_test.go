@@ -0,0 +1,126 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDataDir(t *testing.T) {
+	defer setEnv("XDG_DATA_HOME", "/xdg")()
+	defer setEnv("HOME", "/home/foo")()
+	if dir, err := DataDir(); err != nil || dir != filepath.Join("/xdg", "goyuki") {
+		t.Errorf("DataDir() = %s, %v; want %s", dir, err, filepath.Join("/xdg", "goyuki"))
+	}
+
+	os.Setenv("XDG_DATA_HOME", "")
+	want := filepath.Join("/home/foo", ".local", "share", "goyuki")
+	if dir, err := DataDir(); err != nil || dir != want {
+		t.Errorf("DataDir() = %s, %v; want %s", dir, err, want)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	h, err := OpenHistory(filepath.Join(dir, "data", HistoryFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	if runs, err := h.Runs("", ""); err != nil || len(runs) != 0 {
+		t.Fatalf("Runs() = %v, %v; want empty", runs, err)
+	}
+
+	records := []*RunRecord{
+		NewRunRecord(&Info{No: "101", Number: 1, Site: "yukicoder"}, "Go", "a/main.go", []byte("wa"), []*CaseResult{{Name: "1", Verdict: "WA"}}),
+		NewRunRecord(&Info{No: "102", Number: 2, Site: "yukicoder"}, "Go", "b/main.go", []byte("ac"), []*CaseResult{{Name: "1", Verdict: "AC"}}),
+		NewRunRecord(&Info{No: "101", Number: 1, Site: "yukicoder"}, "Go", "a/main.go", []byte("ac"), []*CaseResult{{Name: "1", Verdict: "AC"}}),
+		NewRunRecord(&Info{No: "1", Site: "atcoder"}, "Go", "c/main.go", []byte("ac"), []*CaseResult{{Name: "1", Verdict: "AC"}}),
+	}
+	for n, r := range records {
+		if err := h.Add(r); err != nil {
+			t.Fatal(err)
+		}
+		if r.ID != uint64(n+1) {
+			t.Errorf("ID = %d; want %d", r.ID, n+1)
+		}
+	}
+
+	runs, err := h.Runs("yukicoder", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 2 || runs[0].ID != 1 || runs[1].ID != 3 {
+		t.Fatalf("Runs(1) = %v; want runs 1 and 3", runs)
+	}
+	if runs[0].Source != "main.go" || runs[0].Code != "wa" || runs[0].Cases[0].Verdict != "WA" {
+		t.Errorf("Runs(1)[0] = %+v", runs[0])
+	}
+	if runs[0].SourceHash == runs[1].SourceHash {
+		t.Errorf("SourceHash of the different code = %s", runs[0].SourceHash)
+	}
+
+	if runs[0].Label() != "No.1" {
+		t.Errorf("Label() = %s; want No.1", runs[0].Label())
+	}
+
+	if r, err := h.Get(2); err != nil || r.Site != "yukicoder" || r.Problem != "2" {
+		t.Errorf("Get(2) = %+v, %v; want problem 2 of yukicoder", r, err)
+	}
+	if r, err := h.Get(4); err != nil || r.Site != "atcoder" || r.Problem != "1" || r.Label() != "1" {
+		t.Errorf("Get(4) = %+v, %v; want problem 1 of atcoder", r, err)
+	}
+	if _, err := h.Get(5); err == nil {
+		t.Errorf("Get(5) returns no error")
+	}
+}
+
+func TestRunRecordSummary(t *testing.T) {
+	testCases := []struct {
+		cases   []*CaseResult
+		verdict string
+		ac      int
+		time    int64
+		memory  int64
+	}{
+		{cases: nil, verdict: "-"},
+		{
+			cases: []*CaseResult{
+				{Verdict: "AC", Time: 10, Memory: 300},
+				{Verdict: "AC", Time: 20, Memory: 100},
+			},
+			verdict: "AC", ac: 2, time: 20, memory: 300,
+		},
+		{
+			cases: []*CaseResult{
+				{Verdict: "AC", Time: 10},
+				{Verdict: "TLE"},
+				{Verdict: "WA", Time: 5},
+			},
+			verdict: "TLE", ac: 1, time: 10,
+		},
+	}
+
+	for _, testCase := range testCases {
+		r := &RunRecord{Cases: testCase.cases}
+		if v := r.Verdict(); v != testCase.verdict {
+			t.Errorf("Verdict() = %s; want %s", v, testCase.verdict)
+		}
+		if n := r.Accepted(); n != testCase.ac {
+			t.Errorf("Accepted() = %d; want %d", n, testCase.ac)
+		}
+		if tm := r.MaxTime(); tm != testCase.time {
+			t.Errorf("MaxTime() = %d; want %d", tm, testCase.time)
+		}
+		if m := r.MaxMemory(); m != testCase.memory {
+			t.Errorf("MaxMemory() = %d; want %d", m, testCase.memory)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package command
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// UserAgent is sent with every request
+const UserAgent = "goyuki (+https://github.com/yukirin/goyuki)"
+
+// Defaults of NewHTTPClient
+var (
+	DefaultTimeout      = 30 * time.Second
+	DefaultRetry        = 3
+	DefaultBackoff      = time.Second
+	DefaultInterval     = 200 * time.Millisecond
+	DefaultMaxRedirects = 5
+)
+
+// HTTPClient sends requests to the sites with timeout, retries and rate limiting.
+// It has its own http.Client and transport and is safe for concurrent use.
+type HTTPClient struct {
+	// Client sends the requests. Its Timeout is the time limit of a request.
+	Client *http.Client
+
+	// Retry is the number of retries for network errors and 5xx responses.
+	// Only GET and HEAD requests are retried.
+	Retry int
+
+	// Backoff is the wait before the first retry. It doubles on every retry.
+	Backoff time.Duration
+
+	// Interval is the minimum interval between requests
+	Interval time.Duration
+
+	// MaxRedirects is the number of redirects followed.
+	// The last redirect response is returned as is if the limit is reached.
+	MaxRedirects int
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// NewHTTPClient returns a client with the timeout and the retries.
+// The proxy is taken from $HTTP_PROXY, $HTTPS_PROXY and $NO_PROXY.
+func NewHTTPClient(timeout time.Duration, retry int) *HTTPClient {
+	c := &HTTPClient{
+		Retry:        retry,
+		Backoff:      DefaultBackoff,
+		Interval:     DefaultInterval,
+		MaxRedirects: DefaultMaxRedirects,
+	}
+	c.Client = &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		CheckRedirect: c.checkRedirect,
+	}
+	return c
+}
+
+// DefaultHTTPClient is used by the commands which don't have the network options
+var DefaultHTTPClient = NewHTTPClient(DefaultTimeout, DefaultRetry)
+
+type maxRedirectsKey struct{}
+
+// WithMaxRedirects returns the request which follows n redirects instead of MaxRedirects
+func WithMaxRedirects(req *http.Request, n int) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), maxRedirectsKey{}, n))
+}
+
+func (c *HTTPClient) checkRedirect(req *http.Request, via []*http.Request) error {
+	limit := c.MaxRedirects
+	if n, ok := req.Context().Value(maxRedirectsKey{}).(int); ok {
+		limit = n
+	}
+
+	if len(via) > limit {
+		return http.ErrUseLastResponse
+	}
+	return nil
+}
+
+// Get sends a GET request to the url
+func (c *HTTPClient) Get(uri string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(req)
+}
+
+// Do sends the request.
+// A 5xx response is returned as is after the last retry.
+func (c *HTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", UserAgent)
+	}
+
+	retry := 0
+	if req.Method == "" || req.Method == "GET" || req.Method == "HEAD" {
+		retry = c.Retry
+	}
+
+	wait := c.Backoff
+	for n := 0; ; n++ {
+		c.wait()
+		res, err := c.Client.Do(req)
+		if n == retry || !retryable(res, err) {
+			return res, err
+		}
+
+		if res != nil {
+			res.Body.Close()
+		}
+		time.Sleep(wait)
+		wait *= 2
+	}
+}
+
+// wait blocks until Interval has passed since the last request
+func (c *HTTPClient) wait() {
+	c.mu.Lock()
+	now := time.Now()
+	start := c.next
+	if start.Before(now) {
+		start = now
+	}
+	c.next = start.Add(c.Interval)
+	c.mu.Unlock()
+
+	time.Sleep(start.Sub(now))
+}
+
+func retryable(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return res.StatusCode >= 500
+}
@@ -0,0 +1,237 @@
+package command
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testHTTPClient() *HTTPClient {
+	c := NewHTTPClient(time.Second, 2)
+	c.Backoff, c.Interval = time.Millisecond, 0
+	return c
+}
+
+func readBody(res *http.Response) string {
+	b, _ := ioutil.ReadAll(res.Body)
+	return string(b)
+}
+
+func TestHTTPClientRetry(t *testing.T) {
+	count := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		if r.UserAgent() != UserAgent {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if count < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, "ok")
+	}))
+	defer ts.Close()
+
+	res, err := testHTTPClient().Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if body := readBody(res); res.StatusCode != 200 || body != "ok" {
+		t.Errorf("response = %d %s; want 200 ok", res.StatusCode, body)
+	}
+	if count != 3 {
+		t.Errorf("request count = %d; want 3", count)
+	}
+}
+
+func TestHTTPClientGiveUp(t *testing.T) {
+	testCases := []struct {
+		method string
+		status int
+		count  int
+	}{
+		{method: "GET", status: http.StatusInternalServerError, count: 3},
+		{method: "POST", status: http.StatusInternalServerError, count: 1},
+		{method: "GET", status: http.StatusNotFound, count: 1},
+	}
+
+	for _, testCase := range testCases {
+		count := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			count++
+			w.WriteHeader(testCase.status)
+		}))
+
+		req, err := http.NewRequest(testCase.method, ts.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := testHTTPClient().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		ts.Close()
+
+		if res.StatusCode != testCase.status || count != testCase.count {
+			t.Errorf("%s: status = %d, count = %d; want %d, %d", testCase.method, res.StatusCode, count, testCase.status, testCase.count)
+		}
+	}
+}
+
+func TestHTTPClientTimeout(t *testing.T) {
+	var count int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&count, 1)
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer ts.Close()
+
+	c := testHTTPClient()
+	c.Client.Timeout = 50 * time.Millisecond
+	if _, err := c.Get(ts.URL); err == nil {
+		t.Errorf("request should time out")
+	}
+	if n := atomic.LoadInt32(&count); n != 3 {
+		t.Errorf("request count = %d; want 3", n)
+	}
+}
+
+func TestHTTPClientProxy(t *testing.T) {
+	var requested string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.String()
+		fmt.Fprint(w, "proxied")
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	c := testHTTPClient()
+	c.Client.Transport.(*http.Transport).Proxy = http.ProxyURL(proxyURL)
+
+	res, err := c.Get("http://yukicoder.example/problems")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if body := readBody(res); body != "proxied" || !strings.HasSuffix(requested, "yukicoder.example/problems") {
+		t.Errorf("proxy request = %s, body = %s", requested, body)
+	}
+}
+
+func TestHTTPClientInterval(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	c := testHTTPClient()
+	c.Interval = 50 * time.Millisecond
+
+	start := time.Now()
+	for n := 0; n < 3; n++ {
+		res, err := c.Get(ts.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+	}
+	if d := time.Since(start); d < 100*time.Millisecond {
+		t.Errorf("3 requests took %v; want at least 100ms", d)
+	}
+}
+
+func TestHTTPClientRedirect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n int
+		fmt.Sscan(strings.TrimPrefix(r.URL.Path, "/"), &n)
+		if n > 0 {
+			http.Redirect(w, r, fmt.Sprintf("/%d", n-1), http.StatusFound)
+			return
+		}
+		fmt.Fprint(w, "ok")
+	}))
+	defer ts.Close()
+
+	testCases := []struct {
+		path   string
+		max    int
+		status int
+	}{
+		{path: "/1", max: -1, status: http.StatusOK},
+		{path: "/6", max: -1, status: http.StatusFound},
+		{path: "/1", max: 0, status: http.StatusFound},
+		{path: "/1", max: 1, status: http.StatusOK},
+	}
+
+	c := testHTTPClient()
+	for _, testCase := range testCases {
+		req, err := http.NewRequest("GET", ts.URL+testCase.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if testCase.max >= 0 {
+			req = WithMaxRedirects(req, testCase.max)
+		}
+
+		res, err := c.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if res.StatusCode != testCase.status {
+			t.Errorf("%s (max %d): status = %d; want %d", testCase.path, testCase.max, res.StatusCode, testCase.status)
+		}
+	}
+}
+
+func TestHTTPClientConcurrent(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/", http.StatusFound)
+			return
+		}
+		fmt.Fprint(w, "ok")
+	}))
+	defer ts.Close()
+
+	c := testHTTPClient()
+	var wg sync.WaitGroup
+	for n := 0; n < 8; n++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			path := "/"
+			if n%2 == 0 {
+				path = "/redirect"
+			}
+
+			req, _ := http.NewRequest("GET", ts.URL+path, nil)
+			res, err := c.Do(WithMaxRedirects(req, n%4/2))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer res.Body.Close()
+
+			want := http.StatusOK
+			if path == "/redirect" && n%4/2 == 0 {
+				want = http.StatusFound
+			}
+			if res.StatusCode != want {
+				t.Errorf("%s: status = %d; want %d", path, res.StatusCode, want)
+			}
+		}(n)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,134 @@
+package command
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// InfoSchema is the version of info.json written by this goyuki.
+// info.json without Schema was written before versioning and is read as is.
+const InfoSchema = 1
+
+// Info is problem info
+type Info struct {
+	Schema int `json:",omitempty"`
+
+	// No is the problem id used in the site urls
+	No        string
+	Name      string
+	Level     int
+	Time      int
+	Mem       int
+	RLang     string
+	JudgeType int
+
+	// Number is the public problem number
+	Number    int        `json:",omitempty"`
+	Site      string     `json:",omitempty"`
+	URL       string     `json:",omitempty"`
+	Author    string     `json:",omitempty"`
+	Tags      []string   `json:",omitempty"`
+	Contest   string     `json:",omitempty"`
+	Tolerance float64    `json:",omitempty"`
+	FetchedAt *time.Time `json:",omitempty"`
+
+	// Checksum is the sha256 of the test cases (see TestSetChecksum)
+	Checksum string `json:",omitempty"`
+
+	// Files are the test cases and the judge code written by get command.
+	// get -update removes only these files.
+	Files []string `json:",omitempty"`
+}
+
+var toleranceExp = regexp.MustCompile(`(?i)(?:誤差|error)[^\n]*?10\s*\^\s*\{?\s*[-−]\s*(\d+)\s*\}?`)
+
+// ReadInfo reads the problem infomation file in dir
+func ReadInfo(dir string) (*Info, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, InfoFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read info file: %v", err)
+	}
+	return parseInfo(b)
+}
+
+func parseInfo(b []byte) (*Info, error) {
+	i := &Info{}
+	if err := json.Unmarshal(b, i); err != nil {
+		return nil, err
+	}
+
+	if i.Schema > InfoSchema {
+		return nil, fmt.Errorf("info file schema %d is not supported (max %d): update goyuki", i.Schema, InfoSchema)
+	}
+	return i, nil
+}
+
+// sameInfo reports whether the infomation files differ only in FetchedAt
+func sameInfo(a, b []byte) bool {
+	ia, err := parseInfo(a)
+	if err != nil {
+		return false
+	}
+	ib, err := parseInfo(b)
+	if err != nil {
+		return false
+	}
+
+	ia.FetchedAt, ib.FetchedAt = nil, nil
+	return reflect.DeepEqual(ia, ib)
+}
+
+// ProblemID returns the site and the problem id the provider accepts
+// (the problem number on yukicoder, No on the other sites).
+// info.json without Site is taken as yukicoder's.
+func (i *Info) ProblemID() (site, id string) {
+	switch {
+	case i.Number > 0:
+		return "yukicoder", strconv.Itoa(i.Number)
+	case i.Site != "":
+		return i.Site, i.No
+	}
+	return "yukicoder", i.No
+}
+
+// TestSetChecksum returns the sha256 of the test cases in the problem files.
+// The names and contents of test_in and test_out are hashed in name order.
+func TestSetChecksum(files map[string][]byte) string {
+	var names []string
+	for name := range files {
+		if isCaseFile(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s\x00%d\x00", name, len(files[name]))
+		h.Write(files[name])
+	}
+	return fmt.Sprintf("sha256:%x", h.Sum(nil))
+}
+
+// tolerance returns the allowed error stated in the statement (10^{-n}), or 0 if not stated
+func tolerance(statement string) float64 {
+	m := toleranceExp.FindStringSubmatch(statement)
+	if m == nil {
+		return 0
+	}
+
+	n, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0
+	}
+	return math.Pow10(-n)
+}
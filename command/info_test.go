@@ -0,0 +1,90 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadInfo(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	testCases := []struct {
+		json   string
+		name   string
+		result string
+	}{
+		{json: `{"No":"17","Name":"legacy","Level":3,"Time":2,"Mem":256,"RLang":"","JudgeType":0}`, name: "legacy"},
+		{json: `{"Schema":1,"No":"17","Name":"current","Number":1,"Tags":["a"],"FetchedAt":"2016-01-02T03:04:05Z"}`, name: "current"},
+		{json: `{"Schema":99,"No":"17"}`, result: "not supported"},
+	}
+
+	for _, testCase := range testCases {
+		if err := ioutil.WriteFile(filepath.Join(dir, InfoFile), []byte(testCase.json), FPerm); err != nil {
+			t.Fatal(err)
+		}
+
+		i, err := ReadInfo(dir)
+		if testCase.result != "" {
+			if err == nil || !strings.Contains(err.Error(), testCase.result) {
+				t.Errorf("ReadInfo(%s) error = %v; want %s", testCase.json, err, testCase.result)
+			}
+			continue
+		}
+		if err != nil || i.Name != testCase.name {
+			t.Errorf("ReadInfo(%s) = %+v, %v", testCase.json, i, err)
+		}
+	}
+}
+
+func TestSameInfo(t *testing.T) {
+	a := []byte(`{"Schema":1,"No":"1","FetchedAt":"2016-01-02T03:04:05Z"}`)
+	b := []byte(`{"Schema":1,"No":"1","FetchedAt":"2017-01-02T03:04:05Z"}`)
+	c := []byte(`{"Schema":1,"No":"1","Checksum":"sha256:00","FetchedAt":"2017-01-02T03:04:05Z"}`)
+
+	if !sameInfo(a, b) {
+		t.Errorf("infomation which differs only in FetchedAt should be same")
+	}
+	if sameInfo(a, c) {
+		t.Errorf("infomation with different Checksum should differ")
+	}
+}
+
+func TestTestSetChecksum(t *testing.T) {
+	a := map[string][]byte{"test_in/1.txt": []byte("1"), "test_out/1.txt": []byte("2"), InfoFile: []byte("{}")}
+	b := map[string][]byte{"test_out/1.txt": []byte("2"), "test_in/1.txt": []byte("1")}
+	c := map[string][]byte{"test_in/1.txt": []byte("12"), "test_out/1.txt": []byte("")}
+
+	if TestSetChecksum(a) != TestSetChecksum(b) {
+		t.Errorf("checksum should depend on the test cases only")
+	}
+	if TestSetChecksum(a) == TestSetChecksum(c) {
+		t.Errorf("checksum should differ: %s", TestSetChecksum(a))
+	}
+	if !strings.HasPrefix(TestSetChecksum(a), "sha256:") {
+		t.Errorf("checksum = %s", TestSetChecksum(a))
+	}
+}
+
+func TestTolerance(t *testing.T) {
+	testCases := []struct {
+		statement string
+		tolerance float64
+	}{
+		{statement: "絶対誤差または相対誤差が $10^{-6}$ 以下であれば正解", tolerance: 1e-6},
+		{statement: "Your answer is correct if the absolute or relative error is at most $10^{-9}$.", tolerance: 1e-9},
+		{statement: "答えを $10^9+7$ で割った余りを出力", tolerance: 0},
+	}
+
+	for _, testCase := range testCases {
+		if tol := tolerance(testCase.statement); tol != testCase.tolerance {
+			t.Errorf("tolerance(%s) = %v; want %v", testCase.statement, tol, testCase.tolerance)
+		}
+	}
+}
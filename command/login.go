@@ -0,0 +1,112 @@
+package command
+
+import "strings"
+
+// LoginCommand is a Command that stores yukicoder credentials
+type LoginCommand struct {
+	Meta
+
+	// API is used instead of yukicoder API if it is not nil
+	API *APIClient
+
+	// URL is used instead of BaseURL if it is not empty
+	URL string
+}
+
+// Run validates and stores the credentials
+func (c *LoginCommand) Run(args []string) int {
+	var (
+		cookieFlag string
+		tokenFlag  string
+	)
+
+	flags := c.Meta.NewFlagSet("login", c.Help())
+	flags.StringVar(&cookieFlag, "c", "", "REVEL_SESSION cookie")
+	flags.StringVar(&cookieFlag, "cookie", "", "REVEL_SESSION cookie")
+	flags.StringVar(&tokenFlag, "t", "", "API token")
+	flags.StringVar(&tokenFlag, "token", "", "API token")
+
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(c.msg("error.invalidOption", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+	args = flags.Args()
+
+	if len(args) > 0 {
+		c.UI.Error(c.msg("error.invalidArguments", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+
+	if cookieFlag == "" && tokenFlag == "" {
+		var err error
+		if cookieFlag, err = c.UI.AskSecret(c.msg("login.cookie")); err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+		if tokenFlag, err = c.UI.AskSecret(c.msg("login.token")); err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+	}
+	cookieFlag, tokenFlag = strings.TrimSpace(cookieFlag), strings.TrimSpace(tokenFlag)
+
+	if cookieFlag == "" && tokenFlag == "" {
+		c.UI.Error(c.msg("login.noCredentials"))
+		return ExitCodeFailed
+	}
+
+	cred, err := ReadCredential()
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+
+	if cookieFlag != "" {
+		if err := checkCookie(c.baseURL(), cookieFlag); err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+		cred.Cookie = cookieFlag
+	}
+
+	if tokenFlag != "" {
+		if err := checkToken(c.api(tokenFlag)); err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+		cred.Token = tokenFlag
+	}
+
+	if err := cred.Write(); err != nil {
+		c.UI.Error(c.msg("login.saveFailed", err))
+		return ExitCodeFailed
+	}
+
+	p, _ := CredentialPath()
+	c.UI.Info(c.msg("login.done", p))
+	return ExitCodeOK
+}
+
+func (c *LoginCommand) baseURL() string {
+	if c.URL != "" {
+		return c.URL
+	}
+	return BaseURL
+}
+
+func (c *LoginCommand) api(token string) *APIClient {
+	if c.API != nil {
+		return &APIClient{URL: c.API.URL, Token: token}
+	}
+	return NewAPIClient(token)
+}
+
+// Synopsis is a one-line, short synopsis of the command.
+func (c *LoginCommand) Synopsis() string {
+	return c.msg("login.synopsis")
+}
+
+// Help is a long-form help text
+func (c *LoginCommand) Help() string {
+	return strings.TrimSpace(c.msg("login.help"))
+}
@@ -0,0 +1,129 @@
+package command
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestLoginCommand_implement(t *testing.T) {
+	var _ cli.Command = &LoginCommand{}
+	var _ cli.Command = &LogoutCommand{}
+	var _ cli.Command = &WhoamiCommand{}
+}
+
+func TestLoginCommandFlag(t *testing.T) {
+	testCases := []struct {
+		args   []string
+		code   int
+		result string
+	}{
+		{args: []string{"-foo"}, code: ExitCodeFailed, result: "不正なオプション"},
+		{args: []string{"hoge"}, code: ExitCodeFailed, result: "不正な引数"},
+		{args: []string{}, code: ExitCodeFailed, result: "認証情報が入力されていません"},
+	}
+
+	for _, testCase := range testCases {
+		ui := &cli.MockUi{InputReader: iotest.OneByteReader(strings.NewReader("\n\n"))}
+		c := &LoginCommand{
+			Meta: Meta{
+				UI: ui,
+			},
+		}
+
+		code := c.Run(testCase.args)
+		errs := ui.ErrorWriter.String()
+
+		if code != testCase.code || !strings.Contains(errs, testCase.result) {
+			t.Errorf("bad status code = %v; want %v\nError message = %s; want %s", code, testCase.code, errs, testCase.result)
+		}
+	}
+}
+
+func TestLoginCommand(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/problems/no/1/testcase.zip":
+			if s, err := r.Cookie("REVEL_SESSION"); err != nil || s.Value != "cookie" {
+				w.WriteHeader(http.StatusForbidden)
+			}
+		case "/api/problems/no/1/file/in":
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`["1.txt"]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	_, clearFunc, err := tmpConfigDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clearFunc()
+	defer setEnv("GOYUKI", "")()
+	defer setEnv("GOYUKI_TOKEN", "")()
+
+	api := &APIClient{URL: ts.URL + "/api"}
+	url := ts.URL + "/problems"
+
+	testCases := []struct {
+		args   []string
+		code   int
+		result string
+	}{
+		{args: []string{"-cookie", "wrong"}, code: ExitCodeFailed, result: "無効なcookie"},
+		{args: []string{"-token", "wrong"}, code: ExitCodeFailed, result: "APIの認証に失敗しました"},
+		{args: []string{"-cookie", "cookie", "-token", "secret"}, code: ExitCodeOK, result: ""},
+	}
+
+	for _, testCase := range testCases {
+		ui := new(cli.MockUi)
+		c := &LoginCommand{Meta: Meta{UI: ui}, API: api, URL: url}
+
+		code := c.Run(testCase.args)
+		errs := ui.ErrorWriter.String()
+		if code != testCase.code || !strings.Contains(errs, testCase.result) {
+			t.Errorf("bad status code = %v; want %v\nError message = %s; want %s", code, testCase.code, errs, testCase.result)
+		}
+	}
+
+	cred, err := ReadCredential()
+	if err != nil || cred.Cookie != "cookie" || cred.Token != "secret" {
+		t.Fatalf("stored credential = %+v, %v", cred, err)
+	}
+
+	ui := new(cli.MockUi)
+	c := &WhoamiCommand{Meta: Meta{UI: ui}, API: api, URL: url}
+	if code := c.Run([]string{}); code != ExitCodeOK {
+		t.Errorf("whoami status code = %v; want %v\n%s", code, ExitCodeOK, ui.ErrorWriter.String())
+	}
+	if out := ui.OutputWriter.String(); strings.Count(out, "有効") != 2 {
+		t.Errorf("whoami output = %s", out)
+	}
+
+	setEnv("GOYUKI_TOKEN", "wrong")
+	ui = new(cli.MockUi)
+	c = &WhoamiCommand{Meta: Meta{UI: ui}, API: api, URL: url}
+	if code := c.Run([]string{}); code != ExitCodeFailed {
+		t.Errorf("whoami status code = %v; want %v", code, ExitCodeFailed)
+	}
+	if out := ui.OutputWriter.String(); !strings.Contains(out, "$GOYUKI_TOKEN\t無効") {
+		t.Errorf("whoami output = %s", out)
+	}
+
+	ui = new(cli.MockUi)
+	if code := (&LogoutCommand{Meta: Meta{UI: ui}}).Run([]string{}); code != ExitCodeOK {
+		t.Errorf("logout status code = %v; want %v", code, ExitCodeOK)
+	}
+	if cred, _ := ReadCredential(); cred.Cookie != "" || cred.Token != "" {
+		t.Errorf("credential after logout = %+v", cred)
+	}
+}
@@ -0,0 +1,43 @@
+package command
+
+import (
+	"os"
+	"strings"
+)
+
+// LogoutCommand is a Command that removes the stored credentials
+type LogoutCommand struct {
+	Meta
+}
+
+// Run removes the credential file
+func (c *LogoutCommand) Run(args []string) int {
+	flags := c.Meta.NewFlagSet("logout", c.Help())
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(c.msg("error.invalidOption", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+
+	err := RemoveCredential()
+	if os.IsNotExist(err) {
+		c.UI.Warn(c.msg("logout.notLoggedIn"))
+		return ExitCodeOK
+	}
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+
+	c.UI.Info(c.msg("logout.done"))
+	return ExitCodeOK
+}
+
+// Synopsis is a one-line, short synopsis of the command.
+func (c *LogoutCommand) Synopsis() string {
+	return c.msg("logout.synopsis")
+}
+
+// Help is a long-form help text
+func (c *LogoutCommand) Help() string {
+	return strings.TrimSpace(c.msg("logout.help"))
+}
@@ -0,0 +1,72 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Locales of the message catalogs
+const (
+	LocaleJa = "ja"
+	LocaleEn = "en"
+)
+
+// Catalogs are the message catalogs keyed by the locale.
+// All catalogs have the same keys.
+var Catalogs = map[string]map[string]string{
+	LocaleJa: jaCatalog,
+	LocaleEn: enCatalog,
+}
+
+// DetectLocale returns the locale of $LC_ALL, $LC_MESSAGES or $LANG.
+// Japanese is used if they are not set, English for the C locale.
+func DetectLocale() string {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := os.Getenv(env)
+		if v == "" {
+			continue
+		}
+
+		if strings.HasPrefix(v, "ja") {
+			return LocaleJa
+		}
+		return LocaleEn
+	}
+	return LocaleJa
+}
+
+// Message returns the message of the key in the locale formatted with args.
+// The Japanese message is used for an unknown locale.
+func Message(locale, key string, args ...interface{}) string {
+	catalog, ok := Catalogs[locale]
+	if !ok {
+		catalog = Catalogs[LocaleJa]
+	}
+
+	msg, ok := catalog[key]
+	if !ok {
+		return key
+	}
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// msgError is an error with the message of the key in the catalogs.
+// Error returns the message in the locale of the environment,
+// the commands print it in their locale (see Meta.errMsg).
+type msgError struct {
+	key  string
+	args []interface{}
+}
+
+// newMsgError returns the error of the message of the key formatted with args
+func newMsgError(key string, args ...interface{}) error {
+	return &msgError{key: key, args: args}
+}
+
+func (e *msgError) Error() string {
+	return Message(DetectLocale(), e.key, e.args...)
+}
@@ -0,0 +1,124 @@
+package command
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestCatalogKeys(t *testing.T) {
+	verb := regexp.MustCompile(`%[a-z]`)
+	ja := Catalogs[LocaleJa]
+	for locale, catalog := range Catalogs {
+		for key, msg := range ja {
+			tr, ok := catalog[key]
+			if !ok {
+				t.Errorf("%s catalog: missing key %s", locale, key)
+				continue
+			}
+			if a, b := verb.FindAllString(msg, -1), verb.FindAllString(tr, -1); strings.Join(a, "") != strings.Join(b, "") {
+				t.Errorf("%s catalog: %s verbs = %v; want %v", locale, key, b, a)
+			}
+		}
+		for key := range catalog {
+			if _, ok := ja[key]; !ok {
+				t.Errorf("%s catalog: unknown key %s", locale, key)
+			}
+		}
+	}
+}
+
+func TestCatalogCommands(t *testing.T) {
+	var keys []string
+	for key := range Catalogs[LocaleJa] {
+		if strings.HasSuffix(key, ".help") {
+			keys = append(keys, strings.TrimSuffix(key, ".help"))
+		}
+	}
+	sort.Strings(keys)
+
+	for _, name := range keys {
+		for locale, catalog := range Catalogs {
+			if catalog[name+".synopsis"] == "" || !strings.Contains(catalog[name+".help"], "goyuki "+name) {
+				t.Errorf("%s catalog: %s help or synopsis is not complete", locale, name)
+			}
+		}
+	}
+}
+
+func TestDetectLocale(t *testing.T) {
+	testCases := []struct {
+		lcAll  string
+		lang   string
+		locale string
+	}{
+		{"", "", LocaleJa},
+		{"", "ja_JP.UTF-8", LocaleJa},
+		{"", "en_US.UTF-8", LocaleEn},
+		{"", "de_DE.UTF-8", LocaleEn},
+		{"", "C", LocaleEn},
+		{"", "POSIX", LocaleEn},
+		{"", "C.UTF-8", LocaleEn},
+		{"en_US.UTF-8", "ja_JP.UTF-8", LocaleEn},
+	}
+
+	for _, testCase := range testCases {
+		clear1 := setEnv("LC_ALL", testCase.lcAll)
+		clear2 := setEnv("LANG", testCase.lang)
+		if locale := DetectLocale(); locale != testCase.locale {
+			t.Errorf("DetectLocale(LC_ALL=%s, LANG=%s) = %s; want %s", testCase.lcAll, testCase.lang, locale, testCase.locale)
+		}
+		clear2()
+		clear1()
+	}
+}
+
+func TestMessage(t *testing.T) {
+	testCases := []struct {
+		locale string
+		key    string
+		args   []interface{}
+		msg    string
+	}{
+		{LocaleJa, "whoami.valid", nil, "有効"},
+		{LocaleEn, "whoami.valid", nil, "valid"},
+		{LocaleEn, "show.image", []interface{}{"a.png"}, "[image: a.png]"},
+		{"fr", "whoami.valid", nil, "有効"},
+		{LocaleEn, "foo", nil, "foo"},
+	}
+
+	for _, testCase := range testCases {
+		if msg := Message(testCase.locale, testCase.key, testCase.args...); msg != testCase.msg {
+			t.Errorf("Message(%s, %s) = %s; want %s", testCase.locale, testCase.key, msg, testCase.msg)
+		}
+	}
+}
+
+func TestRunCommandHelpLocale(t *testing.T) {
+	c := &RunCommand{Meta: Meta{Config: &Config{Locale: LocaleEn}}}
+	if !strings.HasPrefix(c.Help(), "Compile source_file") || c.Synopsis() != "Compile and run the tests" {
+		t.Errorf("Help() = %s; want English help", c.Help())
+	}
+
+	c = &RunCommand{Meta: Meta{Config: &Config{Locale: LocaleJa}}}
+	if c.Synopsis() != "コンパイル後、テストを実行する" {
+		t.Errorf("Synopsis() = %s; want Japanese synopsis", c.Synopsis())
+	}
+}
+
+func TestMetaErrMsg(t *testing.T) {
+	m := &Meta{Config: &Config{Locale: LocaleEn}}
+	if msg := m.errMsg(newMsgError("history.notFound", 9)); msg != "run not found: 9" {
+		t.Errorf("errMsg() = %s; want English message", msg)
+	}
+	if msg := m.errMsg(fmt.Errorf("foo")); msg != "foo" {
+		t.Errorf("errMsg(foo) = %s; want foo", msg)
+	}
+
+	defer setEnv("LANG", "")()
+	if msg := newMsgError("history.notFound", 9).Error(); msg != "実行が見つかりません: 9" {
+		t.Errorf("Error() = %s; want Japanese message", msg)
+	}
+}
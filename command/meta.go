@@ -17,6 +17,56 @@ import (
 // Meta contain the meta-option that nearly all subcommand inherited.
 type Meta struct {
 	UI cli.Ui
+
+	// Config is the global config loaded at startup
+	Config *Config
+}
+
+// RootEnv is the environment variable of the workspace root.
+// It overrides root in config.toml.
+const RootEnv = "GOYUKI_ROOT"
+
+// root returns the workspace root or "" if the problems are stored in the current directory
+func (m *Meta) root() string {
+	root := os.Getenv(RootEnv)
+	if root == "" {
+		root = m.config().Root
+	}
+
+	if home := os.Getenv("HOME"); home != "" && strings.HasPrefix(root, "~/") {
+		root = filepath.Join(home, root[2:])
+	}
+	return root
+}
+
+// locale returns the locale of the messages (locale in config or DetectLocale)
+func (m *Meta) locale() string {
+	if l := m.config().Locale; l != "" {
+		return l
+	}
+	return DetectLocale()
+}
+
+// msg returns the message of the key in the locale
+func (m *Meta) msg(key string, args ...interface{}) string {
+	return Message(m.locale(), key, args...)
+}
+
+// errMsg returns the message of err in the locale
+func (m *Meta) errMsg(err error) string {
+	if e, ok := err.(*msgError); ok {
+		return m.msg(e.key, e.args...)
+	}
+	return err.Error()
+}
+
+// config returns the global config filled with DefaultConfig
+func (m *Meta) config() *Config {
+	if m.Config == nil {
+		conf := DefaultConfig
+		return &conf
+	}
+	return m.Config.withDefaults()
 }
 
 // LangCmd is struct to fill Lang template
@@ -85,6 +135,37 @@ var Lang = map[string][]string{
 	"f90":   {"gfortran {{.File}} -o ./a.out", "./a.out", "Fortran"},
 }
 
+// SubmitLang is yukicoder language id of Lang
+var SubmitLang = map[string]string{
+	"cpp":   "cpp",
+	"go":    "go",
+	"c":     "c",
+	"rb":    "ruby",
+	"py2":   "python",
+	"py":    "python3",
+	"pypy2": "pypy2",
+	"pypy3": "pypy3",
+	"js":    "node",
+	"java":  "java8",
+	"pl":    "perl",
+	"pl6":   "perl6",
+	"php":   "php",
+	"rs":    "rust",
+	"scala": "scala",
+	"hs":    "haskell",
+	"scm":   "scheme",
+	"sh":    "sh",
+	"txt":   "text",
+	"ml":    "ocaml",
+	"cs":    "csharp",
+	"d":     "d",
+	"nim":   "nim",
+	"kt":    "kotlin",
+	"cr":    "crystal",
+	"fs":    "fsharp",
+	"f90":   "fortran",
+}
+
 // yukicoder Judge Code
 var (
 	AC  = ansi.Color("[AC]", "green+bh")
@@ -95,6 +176,25 @@ var (
 	CE  = ansi.Color("[CE]", "yellow+bh")
 )
 
+// Verdict returns the colored judge code of the result name (AC, WA, ...)
+func Verdict(result string) string {
+	switch result {
+	case "AC":
+		return AC
+	case "WA":
+		return WA
+	case "TLE":
+		return TLE
+	case "MLE":
+		return MLE
+	case "RE":
+		return RE
+	case "CE":
+		return CE
+	}
+	return "[" + result + "]"
+}
+
 // NewFlagSet generates common flag.FlagSet
 // https://github.com/tcnksm/gcli/blob/master/command/meta.go
 func (m *Meta) NewFlagSet(name string, helpText string) *flag.FlagSet {
@@ -115,6 +215,22 @@ func (m *Meta) NewFlagSet(name string, helpText string) *flag.FlagSet {
 	return flags
 }
 
+// ParseInterspersed parses flags placed before, between and after the arguments
+// and returns the remaining arguments
+func ParseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	var rest []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return rest, nil
+		}
+		rest, args = append(rest, args[0]), args[1:]
+	}
+}
+
 // Ext to get an extension from the language
 func Ext(lang string) string {
 	for k, v := range Lang {
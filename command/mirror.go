@@ -0,0 +1,205 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/cli"
+)
+
+// MirrorCommand is a Command that prefetches problems into the cache
+type MirrorCommand struct {
+	Meta
+
+	// HTTP sends the requests. It is made from -timeout and -retry if nil.
+	HTTP *HTTPClient
+
+	// API is used instead of yukicoder API if it is not nil
+	API *APIClient
+
+	// Cache is used instead of the cache in CacheDir if it is not nil
+	Cache *Cache
+}
+
+// Run downloads the problems into the cache
+func (c *MirrorCommand) Run(args []string) int {
+	var (
+		levelFlag    string
+		parallelFlag int
+		forceFlag    bool
+		samplesFlag  bool
+		timeoutFlag  time.Duration
+		retryFlag    int
+	)
+
+	flags := c.Meta.NewFlagSet("mirror", c.Help())
+	flags.StringVar(&levelFlag, "level", "", "Specify level range (min-max)")
+	flags.IntVar(&parallelFlag, "j", c.config().Parallel, "Number of concurrent downloads")
+	flags.IntVar(&parallelFlag, "parallel", c.config().Parallel, "Number of concurrent downloads")
+	flags.BoolVar(&forceFlag, "f", false, "download cached problems again")
+	flags.BoolVar(&forceFlag, "force", false, "download cached problems again")
+	flags.BoolVar(&samplesFlag, "s", false, "download sample cases only")
+	flags.BoolVar(&samplesFlag, "samples", false, "download sample cases only")
+	flags.DurationVar(&timeoutFlag, "timeout", DefaultTimeout, "Time limit of a request")
+	flags.IntVar(&retryFlag, "retry", DefaultRetry, "Number of retries of a failed request")
+
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(c.msg("error.invalidOption", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+	args = flags.Args()
+
+	if len(args) < 1 && levelFlag == "" {
+		c.UI.Error(c.msg("error.invalidArguments", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+
+	if parallelFlag < 1 {
+		c.UI.Error(c.msg("error.invalidParallel", parallelFlag))
+		return ExitCodeFailed
+	}
+
+	if retryFlag < 0 {
+		c.UI.Error(c.msg("get.invalidRetry", retryFlag))
+		return ExitCodeFailed
+	}
+	if c.HTTP == nil {
+		c.HTTP = NewHTTPClient(timeoutFlag, retryFlag)
+	}
+
+	var min, max float64
+	if levelFlag != "" {
+		var err error
+		if min, max, err = levelRange(levelFlag); err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+	}
+
+	cache := c.Cache
+	if cache == nil {
+		var err error
+		if cache, err = NewCache(); err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+	}
+
+	cred, err := LoadCredential()
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+	if cred.Cookie == "" && cred.Token == "" && !samplesFlag {
+		c.UI.Warn(c.msg("error.samplesOnly"))
+		samplesFlag = true
+	}
+
+	client := c.HTTP
+	api := c.API
+	if api == nil {
+		api = NewAPIClient(cred.Token)
+		api.HTTP = client
+	}
+	c.UI = &cli.ConcurrentUi{Ui: c.UI}
+	y := &Yukicoder{
+		UI:      c.UI,
+		Cookie:  cred.Cookie,
+		API:     api,
+		Samples: samplesFlag,
+		HTTP:    client,
+		Locale:  c.locale(),
+	}
+
+	var ids []string
+	for _, arg := range args {
+		id, ok, err := y.Parse(arg)
+		if err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+		if !ok {
+			c.UI.Error(c.msg("error.unsupportedProblem", arg))
+			return ExitCodeFailed
+		}
+		ids = append(ids, id)
+	}
+
+	if levelFlag != "" {
+		ps, err := api.Problems()
+		if err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+		for _, p := range ps {
+			if p.Level >= min && p.Level <= max {
+				ids = append(ids, fmt.Sprint(p.No))
+			}
+		}
+	}
+	sort.Sort(naturalStrings(ids))
+
+	return c.mirror(y, cache, ids, parallelFlag, forceFlag)
+}
+
+// mirror downloads the problems into the cache with parallel workers
+func (c *MirrorCommand) mirror(y *Yukicoder, cache *Cache, ids []string, parallel int, force bool) int {
+	ui := y.UI
+	failed := runParallel(parallel, len(ids), func(n int) error {
+		id := ids[n]
+		// sample cases don't replace the cached test cases
+		if (!force || y.Samples) && cache.Exists(y.Name(), id) {
+			ui.Output(c.msg("mirror.cached", id))
+			return nil
+		}
+
+		defer y.Forget(id)
+		i, pf, err := c.download(y.client(), y, id)
+		if err == nil {
+			err = cache.Store(y.Name(), id, pf, y.Statement(id))
+		}
+		if err != nil {
+			ui.Error(c.msg("error.downloadFailed", "No."+id, c.errMsg(err)))
+			return err
+		}
+		ui.Info(fmt.Sprintf("No.%s\t%s", id, i.Name))
+		return nil
+	})
+	ui.Output(c.msg("mirror.done", len(ids)-failed, len(ids), cache.Dir))
+
+	if failed > 0 {
+		return ExitCodeFailed
+	}
+	return ExitCodeOK
+}
+
+// levelRange parses the level range (min-max or level)
+func levelRange(s string) (float64, float64, error) {
+	strs := strings.SplitN(s, "-", 2)
+	if len(strs) == 1 {
+		strs = append(strs, strs[0])
+	}
+
+	min, err := strconv.ParseFloat(strs[0], 64)
+	if err != nil {
+		return 0, 0, newMsgError("mirror.invalidLevel", s)
+	}
+	max, err := strconv.ParseFloat(strs[1], 64)
+	if err != nil || min > max {
+		return 0, 0, newMsgError("mirror.invalidLevel", s)
+	}
+	return min, max, nil
+}
+
+// Synopsis is a one-line, short synopsis of the command.
+func (c *MirrorCommand) Synopsis() string {
+	return c.msg("mirror.synopsis")
+}
+
+// Help is a long-form help text
+func (c *MirrorCommand) Help() string {
+	return strings.TrimSpace(c.msg("mirror.help"))
+}
@@ -0,0 +1,138 @@
+package command
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestMirrorCommand_implement(t *testing.T) {
+	var _ cli.Command = &MirrorCommand{}
+}
+
+func TestMirrorCommandFlag(t *testing.T) {
+	testCases := []struct {
+		args   []string
+		code   int
+		result string
+	}{
+		{args: []string{"-foo"}, code: ExitCodeFailed, result: "不正なオプション"},
+		{args: []string{}, code: ExitCodeFailed, result: "不正な引数"},
+		{args: []string{"-j", "0", "1"}, code: ExitCodeFailed, result: "不正な並列数"},
+		{args: []string{"-retry", "-1", "1"}, code: ExitCodeFailed, result: "不正なリトライ回数"},
+		{args: []string{"-timeout", "1", "1"}, code: ExitCodeFailed, result: "不正なオプション"},
+		{args: []string{"-level", "3-1"}, code: ExitCodeFailed, result: "不正なレベル"},
+		{args: []string{"-level", "a"}, code: ExitCodeFailed, result: "不正なレベル"},
+		{args: []string{"abc123_a"}, code: ExitCodeFailed, result: "対応していない問題"},
+	}
+
+	_, clearDirs, err := tmpUserDirs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clearDirs()
+
+	for _, testCase := range testCases {
+		ui := new(cli.MockUi)
+		c := &MirrorCommand{
+			Meta: Meta{
+				UI: ui,
+			},
+			Cache: &Cache{Dir: os.TempDir()},
+		}
+
+		code := c.Run(testCase.args)
+		errs := ui.ErrorWriter.String()
+
+		if code != testCase.code || !strings.Contains(errs, testCase.result) {
+			t.Errorf("bad status code = %v; want %v\nError message = %s; want %s", code, testCase.code, errs, testCase.result)
+		}
+	}
+}
+
+func TestMirrorCommandHTTPFlag(t *testing.T) {
+	_, clearDirs, err := tmpUserDirs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clearDirs()
+
+	ui := new(cli.MockUi)
+	c := &MirrorCommand{Meta: Meta{UI: ui}, Cache: &Cache{Dir: os.TempDir()}}
+	c.Run([]string{"-timeout", "5s", "-retry", "1", "-level", "a"})
+	if c.HTTP == nil || c.HTTP.Client.Timeout != 5*time.Second || c.HTTP.Retry != 1 {
+		t.Errorf("HTTP = %+v; want timeout 5s and 1 retry", c.HTTP)
+	}
+}
+
+func TestLevelRange(t *testing.T) {
+	testCases := []struct {
+		s        string
+		min, max float64
+	}{
+		{s: "2", min: 2, max: 2},
+		{s: "1-2.5", min: 1, max: 2.5},
+	}
+
+	for _, testCase := range testCases {
+		min, max, err := levelRange(testCase.s)
+		if err != nil || min != testCase.min || max != testCase.max {
+			t.Errorf("levelRange(%s) = %v, %v, %v; want %v, %v", testCase.s, min, max, err, testCase.min, testCase.max)
+		}
+	}
+}
+
+func TestMirrorCommandCached(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/problems" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `[{"No":1,"Level":1},{"No":2,"Level":2},{"No":3,"Level":3}]`)
+	}))
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	_, clearDirs, err := tmpUserDirs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clearDirs()
+
+	cache := &Cache{Dir: dir}
+	info := map[string][]byte{InfoFile: []byte(`{"No":"2"}`)}
+	for _, id := range []string{"1", "2"} {
+		if err := cache.Store("yukicoder", id, info, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ui := new(cli.MockUi)
+	c := &MirrorCommand{
+		Meta:  Meta{UI: ui},
+		API:   &APIClient{URL: ts.URL},
+		Cache: cache,
+	}
+	if code := c.Run([]string{"-s", "-level", "1.5-2", "1"}); code != ExitCodeOK {
+		t.Fatalf("bad status code = %v; want %v\n%s", code, ExitCodeOK, ui.ErrorWriter.String())
+	}
+
+	out := ui.OutputWriter.String()
+	if !strings.Contains(out, "No.1\tキャッシュ済み") || !strings.Contains(out, "No.2\tキャッシュ済み") || strings.Contains(out, "No.3") {
+		t.Errorf("mirror output = %s", out)
+	}
+	if !strings.Contains(out, "2/2問を") {
+		t.Errorf("mirror output = %s", out)
+	}
+}
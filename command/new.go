@@ -0,0 +1,153 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateDir is the directory of the source templates in the config directory
+const TemplateDir = "templates"
+
+// NewCommand is a Command that creates a source file from the template
+type NewCommand struct {
+	Meta
+}
+
+// TemplateData is passed to the source template
+type TemplateData struct {
+	*Info
+
+	// Lang is the language name
+	Lang string
+	// Date is the creation date (2006-01-02)
+	Date string
+}
+
+// Run creates the source file
+func (c *NewCommand) Run(args []string) int {
+	var (
+		langFlag   string
+		outputFlag string
+		getFlag    bool
+		forceFlag  bool
+	)
+
+	flags := c.Meta.NewFlagSet("new", c.Help())
+	flags.StringVar(&langFlag, "l", c.config().Language, "Specify Language")
+	flags.StringVar(&langFlag, "language", c.config().Language, "Specify Language")
+	flags.StringVar(&outputFlag, "o", "", "Specify source file")
+	flags.StringVar(&outputFlag, "output", "", "Specify source file")
+	flags.BoolVar(&getFlag, "g", false, "get the problem if the directory does not exist")
+	flags.BoolVar(&getFlag, "get", false, "get the problem if the directory does not exist")
+	flags.BoolVar(&forceFlag, "f", false, "overwrite the source file")
+	flags.BoolVar(&forceFlag, "force", false, "overwrite the source file")
+
+	rest, err := ParseInterspersed(flags, args)
+	if err != nil {
+		c.UI.Error(c.msg("error.invalidOption", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+	args = rest
+
+	if len(args) < 1 {
+		c.UI.Error(c.msg("error.invalidArguments", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+
+	lang, ok := Lang[langFlag]
+	if !ok {
+		c.UI.Error(c.msg("error.invalidLanguage", langFlag))
+		return ExitCodeFailed
+	}
+
+	dir, err := ProblemDir(c.root(), args[0])
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		if !getFlag {
+			c.UI.Error(c.msg("new.noProblem", dir))
+			return ExitCodeFailed
+		}
+
+		get := &GetCommand{Meta: c.Meta}
+		if code := get.Run([]string{args[0]}); code != ExitCodeOK {
+			return code
+		}
+	}
+
+	info, err := ReadInfo(dir)
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+
+	if outputFlag == "" {
+		outputFlag = filepath.Join(dir, "main."+langFlag)
+	}
+	if _, err := os.Stat(outputFlag); err == nil && !forceFlag {
+		c.UI.Error(c.msg("new.exists", outputFlag))
+		return ExitCodeFailed
+	}
+
+	tmpl, err := c.template(langFlag)
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+
+	var buf bytes.Buffer
+	data := &TemplateData{Info: info, Lang: lang[2], Date: time.Now().Format("2006-01-02")}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		c.UI.Error(c.msg("new.template", err))
+		return ExitCodeFailed
+	}
+
+	if err := ioutil.WriteFile(outputFlag, buf.Bytes(), FPerm); err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+	c.UI.Info(c.msg("new.created", outputFlag))
+	return ExitCodeOK
+}
+
+// template reads the template of the language.
+// An empty template is used if the template file does not exist.
+func (c *NewCommand) template(key string) (*template.Template, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	p := filepath.Join(dir, TemplateDir, key+".tmpl")
+	b, err := ioutil.ReadFile(p)
+	if os.IsNotExist(err) {
+		c.UI.Warn(c.msg("new.noTemplate", p))
+	} else if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(key).Parse(string(b))
+	if err != nil {
+		return nil, fmt.Errorf("template error: %v", err)
+	}
+	return tmpl, nil
+}
+
+// Synopsis is a one-line, short synopsis of the command.
+func (c *NewCommand) Synopsis() string {
+	return c.msg("new.synopsis")
+}
+
+// Help is a long-form help text
+func (c *NewCommand) Help() string {
+	return strings.TrimSpace(c.msg("new.help"))
+}
@@ -0,0 +1,101 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestNewCommand_implement(t *testing.T) {
+	var _ cli.Command = &NewCommand{}
+}
+
+func TestNewCommandFlag(t *testing.T) {
+	testCases := []struct {
+		args   []string
+		code   int
+		result string
+	}{
+		{args: []string{"-foo"}, code: ExitCodeFailed, result: "不正なオプション"},
+		{args: []string{"-l", "cpp"}, code: ExitCodeFailed, result: "不正な引数"},
+		{args: []string{"-l", "hoge", "testdata/337"}, code: ExitCodeFailed, result: "不正な言語"},
+		{args: []string{"-l", "cpp", "testdata/none"}, code: ExitCodeFailed, result: "が存在しません"},
+	}
+
+	for _, testCase := range testCases {
+		ui := new(cli.MockUi)
+		c := &NewCommand{
+			Meta: Meta{
+				UI: ui,
+			},
+		}
+
+		code := c.Run(testCase.args)
+		errs := ui.ErrorWriter.String()
+
+		if code != testCase.code || !strings.Contains(errs, testCase.result) {
+			t.Errorf("bad status code = %v; want %v\nError message = %s; want %s", code, testCase.code, errs, testCase.result)
+		}
+	}
+}
+
+func TestNewCommand(t *testing.T) {
+	configDir, clearFunc, err := tmpConfigDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clearFunc()
+
+	tmplDir := filepath.Join(configDir, "goyuki", TemplateDir)
+	if err := os.MkdirAll(tmplDir, DPerm); err != nil {
+		t.Fatal(err)
+	}
+	tmpl := "// {{.Name}} {{.URL}}\n// {{.Time}} sec {{.Mem}} MB {{.Lang}}\nint main(){}\n"
+	if err := ioutil.WriteFile(filepath.Join(tmplDir, "cpp.tmpl"), []byte(tmpl), FPerm); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(configDir, "1")
+	if err := os.MkdirAll(dir, DPerm); err != nil {
+		t.Fatal(err)
+	}
+	info := `{"No":"17","Name":"道のショートカット","Time":5,"Mem":256,"URL":"https://yukicoder.me/problems/no/1"}`
+	if err := ioutil.WriteFile(filepath.Join(dir, InfoFile), []byte(info), FPerm); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := new(cli.MockUi)
+	c := &NewCommand{Meta: Meta{UI: ui}}
+	if code := c.Run([]string{dir, "-l", "cpp"}); code != ExitCodeOK {
+		t.Fatalf("bad status code = %v; want %v\n%s", code, ExitCodeOK, ui.ErrorWriter.String())
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "main.cpp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "// 道のショートカット https://yukicoder.me/problems/no/1\n// 5 sec 256 MB C++11\nint main(){}\n"
+	if string(b) != expected {
+		t.Errorf("source = %q; want %q", b, expected)
+	}
+
+	ui = new(cli.MockUi)
+	c = &NewCommand{Meta: Meta{UI: ui}}
+	if code := c.Run([]string{"-l", "cpp", dir}); code != ExitCodeFailed || !strings.Contains(ui.ErrorWriter.String(), "既に存在します") {
+		t.Errorf("bad status code = %v; want %v\n%s", code, ExitCodeFailed, ui.ErrorWriter.String())
+	}
+
+	ui = new(cli.MockUi)
+	c = &NewCommand{Meta: Meta{UI: ui}}
+	out := filepath.Join(dir, "a.py")
+	if code := c.Run([]string{"-l", "py", "-o", out, dir}); code != ExitCodeOK || !strings.Contains(ui.ErrorWriter.String(), "テンプレートが見つかりません") {
+		t.Errorf("bad status code = %v; want %v\n%s", code, ExitCodeOK, ui.ErrorWriter.String())
+	}
+	if fi, err := os.Stat(out); err != nil || fi.Size() != 0 {
+		t.Errorf("empty source is not created: %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ProblemConfigFile is the per-problem config file next to info.json
+const ProblemConfigFile = "goyuki.toml"
+
+// ProblemConfig is the per-problem config of the run command.
+// The paths are relative to the problem directory.
+type ProblemConfig struct {
+	// Language is the language name of Lang
+	Language string `toml:"language"`
+	// Source is the default source file
+	Source    string  `toml:"source"`
+	Validater string  `toml:"validater"`
+	Place     int     `toml:"place"`
+	Tolerance float64 `toml:"tolerance"`
+	// TimeScale is the multiplier of the time limit
+	TimeScale float64 `toml:"time_scale"`
+	// Checker is the source file of the output checker
+	Checker string `toml:"checker"`
+}
+
+// ReadProblemConfig reads the config file in dir.
+// An empty config is returned if the file does not exist.
+func ReadProblemConfig(dir string) (*ProblemConfig, error) {
+	pc := &ProblemConfig{}
+	p := filepath.Join(dir, ProblemConfigFile)
+	if _, err := os.Stat(p); os.IsNotExist(err) {
+		return pc, nil
+	}
+
+	md, err := toml.DecodeFile(p, pc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", ProblemConfigFile, err)
+	}
+	if keys := md.Undecoded(); len(keys) > 0 {
+		return nil, fmt.Errorf("unknown key in %s: %s", ProblemConfigFile, keys[0])
+	}
+
+	if pc.Source != "" && !filepath.IsAbs(pc.Source) {
+		pc.Source = filepath.Join(dir, pc.Source)
+	}
+	if pc.Checker != "" && !filepath.IsAbs(pc.Checker) {
+		pc.Checker = filepath.Join(dir, pc.Checker)
+	}
+	return pc, nil
+}
+
+// apply sets the config to the flags which are not set on the command line.
+// The checker is not applied if the validater is set on the command line.
+func (pc *ProblemConfig) apply(flags *flag.FlagSet) error {
+	set := map[string]bool{}
+	flags.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	opts := []struct {
+		key   string
+		value string
+		// names are the flags overriding the key
+		names []string
+	}{
+		{"language", pc.Language, []string{"l", "language"}},
+		{"validater", pc.Validater, []string{"V", "validater"}},
+		{"place", strconv.Itoa(pc.Place), []string{"p", "place"}},
+		{"tolerance", strconv.FormatFloat(pc.Tolerance, 'g', -1, 64), []string{"tolerance"}},
+		{"time_scale", strconv.FormatFloat(pc.TimeScale, 'g', -1, 64), []string{"time-scale"}},
+		{"checker", pc.Checker, []string{"checker", "V", "validater"}},
+	}
+
+	for _, opt := range opts {
+		if opt.value == "" || opt.value == "0" {
+			continue
+		}
+
+		skip := false
+		for _, name := range opt.names {
+			skip = skip || set[name]
+		}
+		if skip {
+			continue
+		}
+
+		if err := flags.Set(opt.names[0], opt.value); err != nil {
+			return newMsgError("problemconfig.invalidValue", opt.key, ProblemConfigFile, opt.value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package command
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func writeProblemConfig(t *testing.T, config string) (string, func()) {
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, ProblemConfigFile), []byte(config), FPerm); err != nil {
+		t.Fatal(err)
+	}
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func TestReadProblemConfig(t *testing.T) {
+	dir, clear := writeProblemConfig(t, `
+language = "cpp"
+source = "main.cpp"
+validater = "float"
+place = 6
+tolerance = 1e-6
+time_scale = 1.5
+checker = "/usr/local/bin/checker.go"
+`)
+	defer clear()
+
+	pc, err := ReadProblemConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &ProblemConfig{
+		Language:  "cpp",
+		Source:    filepath.Join(dir, "main.cpp"),
+		Validater: "float",
+		Place:     6,
+		Tolerance: 1e-6,
+		TimeScale: 1.5,
+		Checker:   "/usr/local/bin/checker.go",
+	}
+	if !reflect.DeepEqual(pc, want) {
+		t.Errorf("ReadProblemConfig = %+v; want %+v", pc, want)
+	}
+}
+
+func TestReadProblemConfigError(t *testing.T) {
+	testCases := []struct {
+		config string
+		result string
+	}{
+		{config: `language = `, result: "failed to read"},
+		{config: `timescale = 2`, result: "unknown key"},
+		{config: `place = "6"`, result: "failed to read"},
+	}
+
+	for _, testCase := range testCases {
+		dir, clear := writeProblemConfig(t, testCase.config)
+		_, err := ReadProblemConfig(dir)
+		clear()
+
+		if err == nil || !strings.Contains(err.Error(), testCase.result) {
+			t.Errorf("ReadProblemConfig(%q) error = %v; want %s", testCase.config, err, testCase.result)
+		}
+	}
+}
+
+func TestReadProblemConfigNotExist(t *testing.T) {
+	pc, err := ReadProblemConfig("testdata/337")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(pc, &ProblemConfig{}) {
+		t.Errorf("ReadProblemConfig = %+v; want empty config", pc)
+	}
+}
+
+func TestProblemConfigApply(t *testing.T) {
+	pc := &ProblemConfig{
+		Language:  "cpp",
+		Validater: "float",
+		Place:     6,
+		TimeScale: 2,
+		Checker:   "checker.go",
+	}
+
+	testCases := []struct {
+		args      []string
+		lang      string
+		validater string
+		place     int
+		scale     float64
+		checker   string
+	}{
+		{args: []string{}, lang: "cpp", validater: "float", place: 6, scale: 2, checker: "checker.go"},
+		{args: []string{"-l", "go", "-place", "3"}, lang: "go", validater: "float", place: 3, scale: 2, checker: "checker.go"},
+		{args: []string{"-V", "diff"}, lang: "cpp", validater: "diff", place: 6, scale: 2},
+		{args: []string{"-time-scale", "1", "-checker", "foo.go"}, lang: "cpp", validater: "float", place: 6, scale: 1, checker: "foo.go"},
+	}
+
+	for _, testCase := range testCases {
+		var (
+			lang, validater, checker string
+			place                    int
+			scale                    float64
+		)
+		flags := flag.NewFlagSet("run", flag.ContinueOnError)
+		flags.StringVar(&lang, "l", "", "")
+		flags.StringVar(&lang, "language", "", "")
+		flags.StringVar(&validater, "V", "", "")
+		flags.StringVar(&validater, "validater", "", "")
+		flags.IntVar(&place, "p", 0, "")
+		flags.IntVar(&place, "place", 0, "")
+		flags.Float64Var(&scale, "tolerance", 0, "")
+		flags.Float64Var(&scale, "time-scale", 0, "")
+		flags.StringVar(&checker, "checker", "", "")
+
+		if err := flags.Parse(testCase.args); err != nil {
+			t.Fatal(err)
+		}
+		if err := pc.apply(flags); err != nil {
+			t.Fatal(err)
+		}
+
+		if lang != testCase.lang || validater != testCase.validater || place != testCase.place || scale != testCase.scale || checker != testCase.checker {
+			t.Errorf("apply(%v) = %s %s %d %v %s; want %s %s %d %v %s", testCase.args,
+				lang, validater, place, scale, checker,
+				testCase.lang, testCase.validater, testCase.place, testCase.scale, testCase.checker)
+		}
+	}
+}
+
+func TestRunCommandProblemConfig(t *testing.T) {
+	dir, clear := writeProblemConfig(t, "language = \"lang\"\nsource = \"main.go\"\n")
+	defer clear()
+
+	b, err := ioutil.ReadFile("testdata/337/info.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, InfoFile), b, FPerm); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		args   []string
+		result string
+	}{
+		{args: []string{dir}, result: "不正な言語です: lang"},
+		{args: []string{"-l", "foo", dir}, result: "不正な言語です: foo"},
+		{args: []string{"-time-scale", "-1", "-l", "go", dir}, result: "不正な時間倍率"},
+		{args: []string{"-tolerance", "-1", "-l", "go", dir}, result: "不正な許容誤差"},
+	}
+
+	for _, testCase := range testCases {
+		ui := new(cli.MockUi)
+		c := &RunCommand{Meta: Meta{UI: ui}}
+
+		code := c.Run(testCase.args)
+		errs := ui.ErrorWriter.String()
+		if code != ExitCodeFailed || !strings.Contains(errs, testCase.result) {
+			t.Errorf("Run(%v) = %d, %s; want %d, %s", testCase.args, code, errs, ExitCodeFailed, testCase.result)
+		}
+	}
+}
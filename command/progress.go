@@ -0,0 +1,137 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProgressInterval is the update interval of the progress line
+var ProgressInterval = 100 * time.Millisecond
+
+var spinner = []string{"|", "/", "-", "\\"}
+
+// Progress shows the progress of the test cases.
+// On a terminal the progress line (spinner, current case, elapsed time vs limit and verdict tallies)
+// is updated in place below the results, otherwise only the results are written as plain lines.
+type Progress struct {
+	// Output writes a result line
+	Output func(string)
+
+	// W is the terminal to show the progress line in, or nil
+	W     io.Writer
+	Total int
+	Limit time.Duration
+
+	mu      sync.Mutex
+	name    string
+	start   time.Time
+	done    int
+	frame   int
+	tallies map[string]int
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewProgress returns the progress of total cases.
+// The progress line is shown in w if it is not nil.
+func NewProgress(output func(string), w io.Writer, total int, limit time.Duration) *Progress {
+	return &Progress{
+		Output:  output,
+		W:       w,
+		Total:   total,
+		Limit:   limit,
+		tallies: map[string]int{},
+	}
+}
+
+// Start starts the case
+func (p *Progress) Start(name string) {
+	p.mu.Lock()
+	p.name, p.start = name, time.Now()
+	p.mu.Unlock()
+
+	if p.W == nil {
+		return
+	}
+
+	p.stop = make(chan struct{})
+	p.wg.Add(1)
+	go func(stop chan struct{}) {
+		defer p.wg.Done()
+		ticker := time.NewTicker(ProgressInterval)
+		defer ticker.Stop()
+
+		for {
+			p.draw()
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}(p.stop)
+}
+
+// Done finishes the current case with the result and writes the line
+func (p *Progress) Done(r *CaseResult, line string) {
+	p.Print(line)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done++
+	p.tallies[r.Verdict]++
+}
+
+// Print stops the progress line and writes the line
+func (p *Progress) Print(line string) {
+	p.Stop()
+	p.Output(line)
+}
+
+// Stop stops and clears the progress line
+func (p *Progress) Stop() {
+	if p.W == nil || p.stop == nil {
+		return
+	}
+
+	close(p.stop)
+	p.wg.Wait()
+	p.stop = nil
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fmt.Fprint(p.W, "\r\x1b[K")
+}
+
+// Tally returns the verdict tallies ([AC] 2  [WA] 1)
+func (p *Progress) Tally() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.tally()
+}
+
+func (p *Progress) tally() string {
+	var strs []string
+	for _, v := range []string{"AC", "WA", "TLE", "MLE", "RE", "CE"} {
+		if n := p.tallies[v]; n > 0 || v == "AC" {
+			strs = append(strs, fmt.Sprintf("%s %d", Verdict(v), n))
+		}
+	}
+	return strings.Join(strs, "  ")
+}
+
+// draw updates the progress line in place
+func (p *Progress) draw() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	elapsed := time.Now().Sub(p.start)
+	line := fmt.Sprintf("%s [%d/%d] %s  %.1fs / %.1fs  %s",
+		spinner[p.frame%len(spinner)], p.done+1, p.Total, p.name,
+		elapsed.Seconds(), p.Limit.Seconds(), p.tally())
+	p.frame++
+	fmt.Fprint(p.W, "\r\x1b[K"+line)
+}
@@ -0,0 +1,59 @@
+package command
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProgressPlain(t *testing.T) {
+	var lines []string
+	p := NewProgress(func(s string) { lines = append(lines, s) }, nil, 3, time.Second)
+
+	for _, r := range []*CaseResult{
+		{Name: "1.txt", Verdict: "AC"},
+		{Name: "2.txt", Verdict: "WA"},
+		{Name: "3.txt", Verdict: "AC"},
+	} {
+		p.Start(r.Name)
+		p.Done(r, r.Verdict+"\t"+r.Name)
+	}
+
+	want := []string{"AC\t1.txt", "WA\t2.txt", "AC\t3.txt"}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("lines = %q; want %q", lines, want)
+	}
+	if tally := p.Tally(); tally != AC+" 2  "+WA+" 1" {
+		t.Errorf("Tally() = %q; want AC 2, WA 1", tally)
+	}
+}
+
+func TestProgressTerminal(t *testing.T) {
+	defer func(interval time.Duration) { ProgressInterval = interval }(ProgressInterval)
+	ProgressInterval = time.Millisecond
+
+	var buf bytes.Buffer
+	var lines []string
+	p := NewProgress(func(s string) { lines = append(lines, s) }, &buf, 2, 2*time.Second)
+
+	p.Start("1.txt")
+	time.Sleep(10 * time.Millisecond)
+	p.Done(&CaseResult{Name: "1.txt", Verdict: "TLE"}, "TLE\t1.txt")
+
+	p.Start("2.txt")
+	p.Stop()
+
+	out := buf.String()
+	for _, s := range []string{"[1/2] 1.txt", "/ 2.0s", "[2/2] 2.txt", TLE + " 1", "\r\x1b[K"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("progress line = %q; want to contain %q", out, s)
+		}
+	}
+	if !strings.HasSuffix(out, "\r\x1b[K") {
+		t.Errorf("progress line = %q; want cleared", out)
+	}
+	if len(lines) != 1 || lines[0] != "TLE\t1.txt" {
+		t.Errorf("lines = %q; want the result line only", lines)
+	}
+}
@@ -0,0 +1,129 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Provider is the interface of judge site.
+// Test cases are keyed by the slash-separated path relative to the problem directory
+// (test_in/name, test_out/name) so that all sites produce the layout run command consumes.
+type Provider interface {
+	// Name returns the site name
+	Name() string
+	// Parse returns the problem id if spec is a problem of the site.
+	// ok is false if spec is not a problem of the site.
+	Parse(spec string) (id string, ok bool, err error)
+	// Info gets the problem infomation
+	Info(id string) (*Info, error)
+	// TestCases gets the test case files
+	TestCases(id string, i *Info) (map[string][]byte, error)
+	// JudgeCode gets the special or reactive judge code
+	JudgeCode(id string, i *Info) ([]byte, error)
+	// SamplesOnly reports whether TestCases returns the sample cases only
+	SamplesOnly() bool
+}
+
+// Submitter is implemented by the Provider which supports submission
+type Submitter interface {
+	// Submit submits the source and returns the submission id
+	Submit(id, lang string, source []byte) (string, error)
+}
+
+// Forgetter is implemented by the Provider which keeps the downloaded pages of the problem
+type Forgetter interface {
+	// Forget drops the pages of the problem
+	Forget(id string)
+}
+
+// forget drops the pages kept by the provider
+func forget(p Provider, id string) {
+	if f, ok := p.(Forgetter); ok {
+		f.Forget(id)
+	}
+}
+
+// FindProvider returns the first provider which accepts spec and the problem id
+func FindProvider(providers []Provider, spec string) (Provider, string, error) {
+	for _, p := range providers {
+		id, ok, err := p.Parse(spec)
+		if err != nil {
+			return nil, "", err
+		}
+		if ok {
+			return p, id, nil
+		}
+	}
+	return nil, "", newMsgError("error.unsupportedProblem", spec)
+}
+
+// ProblemDir resolves the problem directory from a directory path,
+// a problem number, a problem url or a problem id.
+// Problems are stored in the directory named after the id of the provider
+// (root/site/id if the workspace root is not empty).
+// spec is returned as is if no provider accepts it.
+func ProblemDir(root, spec string) (string, error) {
+	if fi, err := os.Stat(spec); err == nil && fi.IsDir() {
+		return spec, nil
+	}
+
+	providers, err := Providers()
+	if err != nil {
+		return "", err
+	}
+	for _, p := range providers {
+		id, ok, err := p.Parse(spec)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return problemPath(root, p.Name(), id), nil
+		}
+	}
+	return spec, nil
+}
+
+// Providers returns the providers with the saved credential to resolve the problems
+func Providers() ([]Provider, error) {
+	cred, err := LoadCredential()
+	if err != nil {
+		return nil, err
+	}
+
+	return []Provider{
+		&Yukicoder{API: NewAPIClient(cred.Token)},
+		&AtCoder{},
+		&Codeforces{},
+	}, nil
+}
+
+// problemPath returns the directory of the problem in the workspace root
+func problemPath(root, site, id string) string {
+	if root == "" {
+		return id
+	}
+	return filepath.Join(root, site, id)
+}
+
+// sampleFiles converts sample cases into test case files
+func sampleFiles(samples []*Sample) map[string][]byte {
+	files := map[string][]byte{}
+	for n, sample := range samples {
+		name := fmt.Sprintf("%s%d.txt", SamplePrefix, n+1)
+		files[path.Join(InputDir, name)] = sample.In
+		files[path.Join(OutputDir, name)] = sample.Out
+	}
+	return files
+}
+
+func sampleText(s string) []byte {
+	s = strings.TrimLeft(s, "\r\n")
+	s = strings.Replace(s, "\r\n", "\n", -1)
+	if !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+	return []byte(s)
+}
@@ -0,0 +1,312 @@
+package command
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestFindProvider(t *testing.T) {
+	providers := []Provider{&Yukicoder{}, &AtCoder{}, &Codeforces{}}
+	testCases := []struct {
+		spec     string
+		provider string
+		id       string
+	}{
+		{"337", "yukicoder", "337"},
+		{"http://yukicoder.me/problems/no/337", "yukicoder", "337"},
+		{"https://yukicoder.me/problems/no/337/", "yukicoder", "337"},
+		{"abc123_a", "atcoder", "abc123_a"},
+		{"https://atcoder.jp/contests/abc123/tasks/abc123_a", "atcoder", "abc123_a"},
+		{"1234A", "codeforces", "1234A"},
+		{"https://codeforces.com/contest/1234/problem/B1", "codeforces", "1234B1"},
+		{"codeforces.com/problemset/problem/4/A/", "codeforces", "4A"},
+		{"foobar", "", ""},
+	}
+
+	for _, testCase := range testCases {
+		p, id, err := FindProvider(providers, testCase.spec)
+		if testCase.provider == "" {
+			if err == nil {
+				t.Errorf("FindProvider(%s) = %s; want error", testCase.spec, p.Name())
+			}
+			continue
+		}
+
+		if err != nil || p.Name() != testCase.provider || id != testCase.id {
+			t.Errorf("FindProvider(%s) = %v, %s, %v; want %s, %s", testCase.spec, p, id, err, testCase.provider, testCase.id)
+		}
+	}
+}
+
+func TestYukicoderParseProblemID(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/problems/17" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"No":1,"ProblemId":17}`)
+	}))
+	defer ts.Close()
+
+	y := &Yukicoder{API: &APIClient{URL: ts.URL}}
+	testCases := []struct {
+		spec string
+		id   string
+		ok   bool
+	}{
+		{"1", "1", true},
+		{"https://yukicoder.me/problems/no/1", "1", true},
+		{"https://yukicoder.me/problems/17", "1", true},
+		{"yukicoder.me/problems/17/", "1", true},
+		{"testdata/337", "", false},
+	}
+
+	for _, testCase := range testCases {
+		id, ok, err := y.Parse(testCase.spec)
+		if err != nil || id != testCase.id || ok != testCase.ok {
+			t.Errorf("Parse(%s) = %s, %v, %v; want %s, %v", testCase.spec, id, ok, err, testCase.id, testCase.ok)
+		}
+	}
+
+	if _, _, err := y.Parse("https://yukicoder.me/problems/99999"); err == nil {
+		t.Error("Parse(unknown problem id) = nil; want error")
+	}
+}
+
+func TestYukicoderInfo(t *testing.T) {
+	site := newSiteTestServer(nil)
+	defer site.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer broken.Close()
+	api := newAPITestServer("")
+	defer api.Close()
+
+	testCases := []struct {
+		num  string
+		base string
+		info *Info
+		warn string
+	}{
+		// API and the page
+		{"1", site.URL, &Info{No: "17", Number: 1, Name: "道のショートカット", Level: 3, Tags: []string{"グラフ", "最短経路"}, Time: 2, Mem: 512}, ""},
+		// API only
+		{"1", broken.URL, &Info{No: "17", Number: 1, Name: "道のショートカット", Level: 3, Tags: []string{"グラフ", "最短経路"}, Time: DefaultTime, Mem: DefaultMem}, "503"},
+		// the page only
+		{"2", site.URL, &Info{No: "18", Number: 2, Name: "No.2", Level: 1, Time: 2, Mem: 512}, "問題ページを使用します"},
+		// neither
+		{"2", broken.URL, nil, ""},
+	}
+
+	for _, testCase := range testCases {
+		ui := cli.NewMockUi()
+		y := &Yukicoder{UI: ui, API: &APIClient{URL: api.URL}, Base: testCase.base}
+		i, err := y.Info(testCase.num)
+		if testCase.info == nil {
+			if err == nil {
+				t.Errorf("Info(%s) from %s = %+v; want error", testCase.num, testCase.base, i)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Info(%s) from %s error = %v", testCase.num, testCase.base, err)
+			continue
+		}
+		if !reflect.DeepEqual(i, testCase.info) {
+			t.Errorf("Info(%s) from %s = %+v; want %+v", testCase.num, testCase.base, i, testCase.info)
+		}
+		if warn := ui.ErrorWriter.String(); testCase.warn == "" && warn != "" || !strings.Contains(warn, testCase.warn) {
+			t.Errorf("Info(%s) from %s warning = %q; want %q", testCase.num, testCase.base, warn, testCase.warn)
+		}
+	}
+}
+
+func TestParseJudgeType(t *testing.T) {
+	page := `<html><body><div id="content" data-problem-id="17"><h3>No.1</h3>
+<p>実行時間制限 : 1ケース 2秒 / メモリ制限 : 512 MB%s</p>
+<p>スペシャルな数を求めてください</p>
+</div></body></html>`
+	testCases := []struct {
+		limit     string
+		judgeType int
+	}{
+		{"", Normal},
+		{" / スペシャルジャッジ問題", Special},
+		{" / リアクティブ問題", Reactive},
+	}
+
+	for _, testCase := range testCases {
+		i, err := parse(strings.NewReader(fmt.Sprintf(page, testCase.limit)))
+		if err != nil || i.JudgeType != testCase.judgeType {
+			t.Errorf("parse(%q) = %+v, %v; want judge type %d", testCase.limit, i, err, testCase.judgeType)
+		}
+	}
+}
+
+func TestProblemDir(t *testing.T) {
+	testCases := []struct {
+		spec string
+		dir  string
+	}{
+		{"testdata/337", "testdata/337"},
+		{"337", "337"},
+		{"https://yukicoder.me/problems/no/337", "337"},
+		{"https://atcoder.jp/contests/abc123/tasks/abc123_a", "abc123_a"},
+		{"https://codeforces.com/contest/4/problem/A", "4A"},
+		{"foo/bar", "foo/bar"},
+	}
+
+	for _, testCase := range testCases {
+		dir, err := ProblemDir("", testCase.spec)
+		if err != nil || dir != testCase.dir {
+			t.Errorf("ProblemDir(%s) = %s, %v; want %s", testCase.spec, dir, err, testCase.dir)
+		}
+	}
+}
+
+func TestProblemDirRoot(t *testing.T) {
+	testCases := []struct {
+		spec string
+		dir  string
+	}{
+		{"testdata/337", "testdata/337"},
+		{"337", "/ws/yukicoder/337"},
+		{"https://yukicoder.me/problems/no/337", "/ws/yukicoder/337"},
+		{"https://atcoder.jp/contests/abc123/tasks/abc123_a", "/ws/atcoder/abc123_a"},
+		{"https://codeforces.com/contest/4/problem/A", "/ws/codeforces/4A"},
+		{"foo/bar", "foo/bar"},
+	}
+
+	for _, testCase := range testCases {
+		dir, err := ProblemDir("/ws", testCase.spec)
+		if err != nil || dir != testCase.dir {
+			t.Errorf("ProblemDir(/ws, %s) = %s, %v; want %s", testCase.spec, dir, err, testCase.dir)
+		}
+	}
+}
+
+func TestMetaRoot(t *testing.T) {
+	defer setEnv("HOME", "/home/foo")()
+
+	testCases := []struct {
+		env  string
+		conf string
+		root string
+	}{
+		{"", "", ""},
+		{"", "/ws", "/ws"},
+		{"/env", "/ws", "/env"},
+		{"", "~/ws", "/home/foo/ws"},
+	}
+
+	for _, testCase := range testCases {
+		clear := setEnv(RootEnv, testCase.env)
+		m := &Meta{Config: &Config{Root: testCase.conf}}
+		if root := m.root(); root != testCase.root {
+			t.Errorf("root(%s, %s) = %s; want %s", testCase.env, testCase.conf, root, testCase.root)
+		}
+		clear()
+	}
+}
+
+func TestParseAtCoder(t *testing.T) {
+	page := `<html><body><div id="main-container">
+<span class="h2">A - Five Antennas <a class="btn">解説</a></span>
+<p>実行時間制限: 2 sec / メモリ制限: 1024 MB</p>
+<div id="task-statement"><span class="lang"><span class="lang-ja">
+<div class="part"><section><h3>入力例 1</h3><pre>1
+2
+</pre></section></div>
+<div class="part"><section><h3>出力例 1</h3><pre>Yay!
+</pre></section></div>
+<div class="part"><section><h3>入力例 2</h3><pre>3</pre></section></div>
+<div class="part"><section><h3>出力例 2</h3><pre>:(</pre></section></div>
+</span><span class="lang-en">
+<div class="part"><section><h3>Sample Input 1</h3><pre>1
+2
+</pre></section></div>
+<div class="part"><section><h3>Sample Output 1</h3><pre>Yay!
+</pre></section></div>
+</span></span></div></div></body></html>`
+
+	i, err := parseAtCoder(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Info{Name: "A - Five Antennas", Time: 2, Mem: 1024}
+	if !reflect.DeepEqual(i, want) {
+		t.Errorf("parseAtCoder() = %+v; want %+v", i, want)
+	}
+
+	samples, err := parseAtCoderSamples(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := sampleFiles(samples)
+	wantFiles := map[string][]byte{
+		"test_in/sample_1.txt":  []byte("1\n2\n"),
+		"test_out/sample_1.txt": []byte("Yay!\n"),
+		"test_in/sample_2.txt":  []byte("3\n"),
+		"test_out/sample_2.txt": []byte(":(\n"),
+	}
+	if !reflect.DeepEqual(files, wantFiles) {
+		t.Errorf("sampleFiles() = %q; want %q", files, wantFiles)
+	}
+}
+
+func TestParseCodeforces(t *testing.T) {
+	page := `<html><body><div class="problem-statement">
+<div class="header"><div class="title">A. Watermelon</div>
+<div class="time-limit"><div class="property-title">time limit per test</div>0.5 seconds</div>
+<div class="memory-limit"><div class="property-title">memory limit per test</div>64 megabytes</div></div>
+<div><p>Pete and his friend Billy decided to buy a watermelon.</p></div>
+<div class="sample-tests"><div class="section-title">Examples</div><div class="sample-test">
+<div class="input"><div class="title">Input</div><pre><div class="test-example-line">2</div><div class="test-example-line">1 2</div></pre></div>
+<div class="output"><div class="title">Output</div><pre>YES</pre></div>
+<div class="input"><div class="title">Input</div><pre>3<br/>4 5 6<br/></pre></div>
+<div class="output"><div class="title">Output</div><pre>
+NO
+</pre></div>
+</div></div></div></body></html>`
+
+	i, err := parseCodeforces(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Info{Name: "Watermelon", Time: 1, Mem: 64}
+	if !reflect.DeepEqual(i, want) {
+		t.Errorf("parseCodeforces() = %+v; want %+v", i, want)
+	}
+
+	samples, err := parseCodeforcesSamples(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := sampleFiles(samples)
+	wantFiles := map[string][]byte{
+		"test_in/sample_1.txt":  []byte("2\n1 2\n"),
+		"test_out/sample_1.txt": []byte("YES\n"),
+		"test_in/sample_2.txt":  []byte("3\n4 5 6\n"),
+		"test_out/sample_2.txt": []byte("NO\n"),
+	}
+	if !reflect.DeepEqual(files, wantFiles) {
+		t.Errorf("sampleFiles() = %q; want %q", files, wantFiles)
+	}
+
+	if _, err := parseCodeforces(strings.NewReader("<html></html>")); err == nil {
+		t.Error("parseCodeforces(no statement) = nil; want error")
+	}
+	if url := (&Codeforces{}).URL("1234B1"); url != "https://codeforces.com/contest/1234/problem/B1" {
+		t.Errorf("URL(1234B1) = %s", url)
+	}
+}
@@ -7,8 +7,6 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
-	"path"
-	"path/filepath"
 	"strings"
 	"time"
 )
@@ -16,6 +14,9 @@ import (
 // RunCommand is a Command that run the test
 type RunCommand struct {
 	Meta
+
+	// HistoryPath is used instead of the default history database if it is not empty
+	HistoryPath string
 }
 
 // Result is test result
@@ -25,6 +26,7 @@ type Result struct {
 	compileTime time.Duration
 	lang        string
 	codeLength  int
+	locale      string
 }
 
 func (r *Result) String() string {
@@ -38,15 +40,65 @@ func (r *Result) String() string {
 		s = "Reactive"
 	}
 	strs := make([]string, 6)
-	strs[0] = fmt.Sprintf("\n問題:\t\t%s", r.info.Name)
-	strs[1] = fmt.Sprintf("テスト日時:\t%s", r.date.Format(time.RFC1123))
-	strs[2] = fmt.Sprintf("言語:\t\t%s", r.lang)
-	strs[3] = fmt.Sprintf("コンパイル時間:\t%d ms", r.compileTime.Nanoseconds()/1000000)
-	strs[4] = fmt.Sprintf("コード長:\t%d byte", r.codeLength)
-	strs[5] = fmt.Sprintf("ジャッジタイプ:\t%s\n", s)
+	strs[0] = Message(r.locale, "result.problem", r.info.Name)
+	strs[1] = Message(r.locale, "result.date", r.date.Format(time.RFC1123))
+	strs[2] = Message(r.locale, "result.language", r.lang)
+	strs[3] = Message(r.locale, "result.compileTime", r.compileTime.Nanoseconds()/1000000)
+	strs[4] = Message(r.locale, "result.codeLength", r.codeLength)
+	strs[5] = Message(r.locale, "result.judgeType", s)
 	return strings.Join(strs, "\n")
 }
 
+// CaseResult is the result of a test case
+type CaseResult struct {
+	Name    string `json:"name"`
+	Verdict string `json:"verdict"`
+	// Time is the execution time in ms
+	Time int64 `json:"time"`
+	// Memory is the peak memory usage in KB
+	Memory int64 `json:"memory,omitempty"`
+	Custom bool  `json:"custom,omitempty"`
+}
+
+// newCaseResult parses the judge result ([AC]: 10 ms)
+func newCaseResult(name, result string, custom bool) *CaseResult {
+	r := &CaseResult{Name: name, Verdict: result, Custom: custom}
+	for _, v := range []string{"AC", "WA", "TLE", "MLE", "RE", "CE"} {
+		if strings.HasPrefix(result, Verdict(v)) {
+			r.Verdict = v
+			fmt.Sscanf(strings.TrimPrefix(result, Verdict(v)), ": %d ms", &r.Time)
+			break
+		}
+	}
+	return r
+}
+
+// ResultJSON is the test result in json format
+type ResultJSON struct {
+	Problem     string        `json:"problem"`
+	No          string        `json:"no"`
+	Date        time.Time     `json:"date"`
+	Language    string        `json:"language"`
+	CompileTime int64         `json:"compile_time"`
+	CodeLength  int           `json:"code_length"`
+	JudgeType   int           `json:"judge_type"`
+	Cases       []*CaseResult `json:"cases"`
+}
+
+// JSON returns the result with the case results in json format
+func (r *Result) JSON(cases []*CaseResult) *ResultJSON {
+	return &ResultJSON{
+		Problem:     r.info.Name,
+		No:          r.info.No,
+		Date:        r.date,
+		Language:    r.lang,
+		CompileTime: r.compileTime.Nanoseconds() / 1000000,
+		CodeLength:  r.codeLength,
+		JudgeType:   r.info.JudgeType,
+		Cases:       cases,
+	}
+}
+
 // Run run the test
 func (c *RunCommand) Run(args []string) int {
 	var (
@@ -54,6 +106,11 @@ func (c *RunCommand) Run(args []string) int {
 		validaterFlag string
 		verboseFlag   bool
 		roundFlag     int
+		rootfsFlag    string
+		toleranceFlag float64
+		scaleFlag     float64
+		checkerFlag   string
+		formatFlag    string
 	)
 
 	flags := c.Meta.NewFlagSet("run", c.Help())
@@ -65,160 +122,274 @@ func (c *RunCommand) Run(args []string) int {
 	flags.BoolVar(&verboseFlag, "verbose", false, "increase amount of output")
 	flags.IntVar(&roundFlag, "p", 0, "Rounded to the decimal point p digits")
 	flags.IntVar(&roundFlag, "place", 0, "Rounded to the decimal point place digits")
+	flags.StringVar(&rootfsFlag, "rootfs", "", "Specify root filesystem to compile and run in")
+	flags.Float64Var(&toleranceFlag, "tolerance", 0, "Allowed absolute or relative error")
+	flags.Float64Var(&scaleFlag, "time-scale", 0, "Multiplier of the time limit")
+	flags.StringVar(&checkerFlag, "checker", "", "Specify checker source file")
+	flags.StringVar(&formatFlag, "format", c.config().Format, "Specify output format (text or json)")
 
 	if err := flags.Parse(args); err != nil {
-		msg := fmt.Sprintf("Invalid option: %s", strings.Join(args, " "))
-		c.UI.Error(msg)
+		c.UI.Error(c.msg("error.invalidOption", strings.Join(args, " ")))
 		return ExitCodeFailed
 	}
 	args = flags.Args()
 
-	if len(args) < 2 {
-		msg := fmt.Sprintf("Invalid arguments: %s", strings.Join(args, " "))
-		c.UI.Error(msg)
+	if len(args) < 1 {
+		c.UI.Error(c.msg("error.invalidArguments", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+
+	dir, err := ProblemDir(c.root(), args[0])
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
 		return ExitCodeFailed
 	}
+	args[0] = dir
 
 	if _, err := os.Stat(args[0]); err != nil {
-		c.UI.Error("does not exist (No such directory)")
+		c.UI.Error(c.msg("error.noDirectory"))
 		return ExitCodeFailed
 	}
 
-	if langFlag == "" {
-		langFlag = strings.Replace(path.Ext(args[1]), ".", "", -1)
+	// goyuki.toml is applied to the flags not set on the command line
+	pc, err := ReadProblemConfig(args[0])
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
 	}
-	lang, ok := Lang[langFlag]
-	if !ok {
-		msg := fmt.Sprintf("Invalid language: %s", langFlag)
-		c.UI.Error(msg)
+	if err := pc.apply(flags); err != nil {
+		c.UI.Error(c.errMsg(err))
 		return ExitCodeFailed
 	}
 
-	if roundFlag < 0 || roundFlag > 15 {
-		msg := fmt.Sprintf("Invalid round: %d", roundFlag)
-		c.UI.Error(msg)
+	if len(args) < 2 {
+		if pc.Source == "" {
+			c.UI.Error(c.msg("error.invalidArguments", strings.Join(args, " ")))
+			return ExitCodeFailed
+		}
+		args = append(args, pc.Source)
+	}
+
+	if toleranceFlag < 0 {
+		c.UI.Error(c.msg("run.invalidTolerance", toleranceFlag))
+		return ExitCodeFailed
+	}
+	if scaleFlag < 0 {
+		c.UI.Error(c.msg("run.invalidScale", scaleFlag))
 		return ExitCodeFailed
 	}
 
-	if validaterFlag == "float" {
-		Validaters["float"] = &FloatValidater{Place: roundFlag}
+	lang, err := sourceLang(args[1], langFlag)
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
 	}
 
 	if validaterFlag == "" {
-		validaterFlag = "diff"
+		validaterFlag = c.config().Validater
 	}
-	v, ok := Validaters[validaterFlag]
-	if !ok {
-		msg := fmt.Sprintf("Invalid validater: %s", validaterFlag)
-		c.UI.Error(msg)
+	if formatFlag != "text" && formatFlag != "json" {
+		c.UI.Error(c.msg("run.invalidFormat", formatFlag))
 		return ExitCodeFailed
 	}
-
-	infoBuf, err := ioutil.ReadFile(args[0] + "/" + "info.json")
+	v, err := NewValidater(validaterFlag, roundFlag)
 	if err != nil {
-		c.UI.Error(fmt.Sprintf("failed to read info file: %v", err))
+		c.UI.Error(c.errMsg(err))
 		return ExitCodeFailed
 	}
 
-	info := Info{}
-	if err := json.Unmarshal(infoBuf, &info); err != nil {
-		c.UI.Error(err.Error())
+	info, err := ReadInfo(args[0])
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
 		return ExitCodeFailed
 	}
 
+	if fv, ok := v.(*FloatValidater); ok {
+		fv.Tolerance = toleranceFlag
+		if fv.Tolerance == 0 {
+			fv.Tolerance = info.Tolerance
+		}
+	}
+
+	var sb *Sandbox
+	if rootfsFlag != "" {
+		sb, err = NewSandbox(rootfsFlag, args[0])
+		if err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+	}
+
 	var w, e io.Writer
 	if verboseFlag {
 		w, e = os.Stdout, os.Stderr
 	}
 
-	code, result, clearFunc, err := NewCode(args[1], lang, &info, w, e)
+	code, result, clearFunc, err := NewCode(args[1], lang, info, sb, w, e)
 	if err != nil {
 		c.UI.Output(err.Error())
 		return ExitCodeFailed
 	}
-	c.UI.Output(result.String())
+	result.locale = c.locale()
+	if formatFlag == "text" {
+		c.UI.Output(result.String())
+	}
 	defer clearFunc()
+	code.TimeScale = scaleFlag
+
+	var checker *CheckerValidater
+	if checkerFlag != "" && info.JudgeType == Normal {
+		cLang, err := sourceLang(checkerFlag, "")
+		if err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+
+		cCode, _, clearFunc, err := NewCode(checkerFlag, cLang, info, nil, w, e)
+		if err != nil {
+			c.UI.Error(c.msg("run.checker", c.errMsg(err)))
+			return ExitCodeFailed
+		}
+		defer clearFunc()
+
+		checker = &CheckerValidater{Code: cCode}
+		v = checker
+	}
 
 	var rCode *Code
 	if info.JudgeType > 0 {
-		rCode, clearFunc, err = NewReactiveCode(&info, args[0], w, e)
+		rsb, err := sb.With(code.Dir)
+		if err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+
+		rCode, clearFunc, err = NewReactiveCode(info, args[0], rsb, w, e)
 		if err != nil {
-			c.UI.Error(err.Error())
+			c.UI.Error(c.errMsg(err))
 			return ExitCodeFailed
 		}
 		defer clearFunc()
+		rCode.TimeScale = scaleFlag
 	}
 
-	inputFiles, err := filepath.Glob(strings.Join([]string{args[0], "test_in", "*"}, "/"))
+	cases, warnings, err := TestCases(args[0])
 	if err != nil {
-		msg := fmt.Sprintf("input testcase error: %v", err)
-		c.UI.Error(msg)
+		c.UI.Error(c.errMsg(err))
 		return ExitCodeFailed
 	}
+	for _, warning := range warnings {
+		c.UI.Warn(c.errMsg(warning))
+	}
 
-	outputFiles, err := filepath.Glob(strings.Join([]string{args[0], "test_out", "*"}, "/"))
-	if err != nil {
-		msg := fmt.Sprintf("output testcase error: %v", err)
-		c.UI.Error(msg)
-		return ExitCodeFailed
+	limit := code.TimeLimit()
+	if rCode != nil {
+		limit = rCode.TimeLimit()
+	}
+	progress := NewProgress(c.UI.Output, nil, len(cases), limit)
+	if formatFlag == "json" {
+		progress.Output = func(string) {}
+	} else if !verboseFlag && isTerminal(os.Stdout) {
+		// the program output is mixed up with the progress line in verbose mode
+		progress.W = os.Stdout
 	}
 
-	for i := 0; i < len(inputFiles); i++ {
-		err := func() error {
-			input, err := os.Open(inputFiles[i])
-			if err != nil {
-				return fmt.Errorf("input test file error: %v", err)
-			}
-			defer input.Close()
+	var results []*CaseResult
+	official, custom := SplitCustom(cases)
+	for n, group := range [][]*TestCase{official, custom} {
+		if n == 1 && len(group) > 0 {
+			progress.Print(c.msg("result.custom"))
+		}
 
-			output, err := ioutil.ReadFile(outputFiles[i])
-			if err != nil {
-				return fmt.Errorf("output test file error: %v", err)
-			}
+		for _, tc := range group {
+			progress.Start(tc.Name)
+			err := func() error {
+				input, err := os.Open(tc.In)
+				if err != nil {
+					return fmt.Errorf("input test file error: %v", err)
+				}
+				defer input.Close()
 
-			var result string
-			if info.JudgeType > 0 {
-				result, err = rCode.Reactive(code, inputFiles[i], outputFiles[i], input, w, e)
-			} else {
-				var buf bytes.Buffer
-				result, err = code.Run(v, output, input, &buf, e)
-			}
+				output, err := ioutil.ReadFile(tc.Out)
+				if err != nil {
+					return fmt.Errorf("output test file error: %v", err)
+				}
+
+				var result string
+				if info.JudgeType > 0 {
+					result, err = rCode.Reactive(code, tc.In, tc.Out, input, w, e)
+				} else {
+					if checker != nil {
+						checker.In = tc.In
+					}
+					var buf bytes.Buffer
+					result, err = code.Run(v, output, input, &buf, e)
+				}
+				if err != nil {
+					return err
+				}
+
+				r := newCaseResult(tc.Name, result, n == 1)
+				r.Memory = code.Memory()
+				progress.Done(r, fmt.Sprintf("%s\t%s", result, tc.Name))
+				results = append(results, r)
+				return nil
+			}()
 			if err != nil {
-				return err
+				progress.Stop()
+				c.UI.Error(c.errMsg(err))
+				return ExitCodeFailed
 			}
+		}
+	}
+	if len(results) > 0 {
+		progress.Print(c.msg("result.summary", progress.Tally(), len(results)))
 
-			_, testFile := path.Split(inputFiles[i])
-			c.UI.Output(fmt.Sprintf("%s\t%s", result, testFile))
-			return nil
-		}()
+		// a broken history does not fail the tests
+		if err := c.record(info, result.lang, args[1], results); err != nil {
+			c.UI.Warn(c.msg("run.history", c.errMsg(err)))
+		}
+	}
+
+	if formatFlag == "json" {
+		b, err := json.MarshalIndent(result.JSON(results), "", "  ")
 		if err != nil {
-			c.UI.Error(err.Error())
+			c.UI.Error(c.errMsg(err))
 			return ExitCodeFailed
 		}
+		c.UI.Output(string(b))
 	}
 	return ExitCodeOK
 }
 
+// record adds the run with a copy of the source file to the history
+func (c *RunCommand) record(info *Info, lang, source string, cases []*CaseResult) error {
+	src, err := ioutil.ReadFile(source)
+	if err != nil {
+		return err
+	}
+
+	p := c.HistoryPath
+	if p == "" {
+		if p, err = HistoryPath(); err != nil {
+			return err
+		}
+	}
+
+	h, err := OpenHistory(p)
+	if err != nil {
+		return err
+	}
+	defer h.Close()
+	return h.Add(NewRunRecord(info, lang, source, src, cases))
+}
+
 // Synopsis is a one-line, short synopsis of the command.
 func (c *RunCommand) Synopsis() string {
-	return "コンパイル後、テストを実行する"
+	return c.msg("run.synopsis")
 }
 
 // Help is a long-form help text
 func (c *RunCommand) Help() string {
-	helpText := `
-source_fileをコンパイル後、problem_noで指定された番号の問題のテストを実行する
-
-Usage:
-	goyuki run problem_no source_file
-
-Options:
-	-language=lang, -l		実行する言語を指定します (デフォルト 拡張子から判別)
-	-validater=validater, -V      テストの一致方法を指定します (デフォルト diff validater)
-	-verbose, -vb		コンパイル時、実行時の標準出力、標準エラー出力を表示する
-	-place=n, -p			出力される数値を小数点以下n桁に丸める (float validater時のみ) (0<=n<=15)
-
-
-`
-	return strings.TrimSpace(helpText)
+	return strings.TrimSpace(c.msg("run.help"))
 }
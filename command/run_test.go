@@ -1,6 +1,10 @@
 package command
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -17,12 +21,12 @@ func TestRunCommandFlag(t *testing.T) {
 		code   int
 		result string
 	}{
-		{args: []string{"-foo", "lang", "-hoge"}, code: ExitCodeFailed, result: "Invalid option"},
-		{args: []string{}, code: ExitCodeFailed, result: "Invalid arguments"},
-		{args: []string{"-l", "lang", "testdata/337", "foo.go"}, code: ExitCodeFailed, result: "Invalid language"},
-		{args: []string{"-V", "hoge", "testdata/337", "foo.go"}, code: ExitCodeFailed, result: "Invalid validater"},
-		{args: []string{"-V", "float", "-p", "16", "testdata/337", "foo.go"}, code: ExitCodeFailed, result: "Invalid round"},
-		{args: []string{"-V", "float", "-p", "-1", "testdata/337", "foo.go"}, code: ExitCodeFailed, result: "Invalid round"},
+		{args: []string{"-foo", "lang", "-hoge"}, code: ExitCodeFailed, result: "不正なオプション"},
+		{args: []string{}, code: ExitCodeFailed, result: "不正な引数"},
+		{args: []string{"-l", "lang", "testdata/337", "foo.go"}, code: ExitCodeFailed, result: "不正な言語"},
+		{args: []string{"-V", "hoge", "testdata/337", "foo.go"}, code: ExitCodeFailed, result: "不正なバリデータ"},
+		{args: []string{"-V", "float", "-p", "16", "testdata/337", "foo.go"}, code: ExitCodeFailed, result: "不正な桁数"},
+		{args: []string{"-V", "float", "-p", "-1", "testdata/337", "foo.go"}, code: ExitCodeFailed, result: "不正な桁数"},
 	}
 
 	for _, testCase := range testCases {
@@ -41,3 +45,104 @@ func TestRunCommandFlag(t *testing.T) {
 		}
 	}
 }
+
+func TestNewCaseResult(t *testing.T) {
+	testCases := []struct {
+		result string
+		want   CaseResult
+	}{
+		{result: AC + ": 12 ms", want: CaseResult{Name: "a", Verdict: "AC", Time: 12}},
+		{result: WA + ": 3 ms", want: CaseResult{Name: "a", Verdict: "WA", Time: 3}},
+		{result: TLE, want: CaseResult{Name: "a", Verdict: "TLE"}},
+		{result: RE, want: CaseResult{Name: "a", Verdict: "RE"}},
+	}
+
+	for _, testCase := range testCases {
+		if r := newCaseResult("a", testCase.result, false); *r != testCase.want {
+			t.Errorf("newCaseResult(%q) = %+v; want %+v", testCase.result, r, testCase.want)
+		}
+	}
+}
+
+func TestRunCommandHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	b, err := json.Marshal(&Info{No: "17", Number: 1, Name: "A+B", Time: 1, Mem: 256})
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string][]byte{
+		InfoFile:                             b,
+		"main.sh":                            []byte("read a b\necho $((a + b))\n"),
+		"test_in/1.txt":                      []byte("1 2\n"),
+		"test_out/1.txt":                     []byte("3\n"),
+		"test_in/" + CustomPrefix + "1.txt":  []byte("3 4\n"),
+		"test_out/" + CustomPrefix + "1.txt": []byte("0\n"),
+	}
+	if err := writeFiles(dir, files); err != nil {
+		t.Fatal(err)
+	}
+	source, hist := filepath.Join(dir, "main.sh"), filepath.Join(dir, "history.db")
+
+	ui := cli.NewMockUi()
+	c := &RunCommand{Meta: Meta{UI: ui}, HistoryPath: hist}
+	if code := c.Run([]string{dir, source}); code != ExitCodeOK {
+		t.Fatalf("bad status code = %v; want %v\n%s", code, ExitCodeOK, ui.ErrorWriter.String())
+	}
+
+	// the custom cases are shown after the others
+	out := ui.OutputWriter.String()
+	official := strings.Index(out, "\t1.txt")
+	header := strings.Index(out, "カスタムケース:")
+	custom := strings.Index(out, "\t"+CustomPrefix+"1.txt")
+	if official < 0 || header < official || custom < header {
+		t.Errorf("output = %s; want 1.txt, the custom case header and %s1.txt in order", out, CustomPrefix)
+	}
+
+	ui = cli.NewMockUi()
+	c = &RunCommand{Meta: Meta{UI: ui}, HistoryPath: hist}
+	if code := c.Run([]string{"-format", "json", dir, source}); code != ExitCodeOK {
+		t.Fatalf("bad status code = %v; want %v\n%s", code, ExitCodeOK, ui.ErrorWriter.String())
+	}
+
+	var result ResultJSON
+	if err := json.Unmarshal(ui.OutputWriter.Bytes(), &result); err != nil {
+		t.Fatalf("json output = %s: %v", ui.OutputWriter.String(), err)
+	}
+	if result.Problem != "A+B" || result.No != "17" || result.Language != "Bash" || len(result.Cases) != 2 {
+		t.Fatalf("json output = %+v", result)
+	}
+	if c := result.Cases[0]; c.Name != "1.txt" || c.Verdict != "AC" || c.Custom {
+		t.Errorf("cases[0] = %+v; want the accepted case 1.txt", c)
+	}
+	if c := result.Cases[1]; c.Name != CustomPrefix+"1.txt" || c.Verdict != "WA" || !c.Custom {
+		t.Errorf("cases[1] = %+v; want the wrong custom case %s1.txt", c, CustomPrefix)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), `"time":`) {
+		t.Errorf("json output = %s; want the time of the cases", ui.OutputWriter.String())
+	}
+
+	h, err := OpenHistory(hist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	runs, err := h.Runs("yukicoder", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("%d runs recorded; want 2", len(runs))
+	}
+	r := runs[1]
+	if r.Name != "A+B" || r.Number != 1 || r.Language != "Bash" || r.Source != "main.sh" || r.Code != string(files["main.sh"]) {
+		t.Errorf("run = %+v", r)
+	}
+	if r.Verdict() != "WA" || r.Accepted() != 1 || len(r.Cases) != 2 || !r.Cases[1].Custom {
+		t.Errorf("run cases = %v, %d accepted; want WA, 1 accepted", r.Verdict(), r.Accepted())
+	}
+}
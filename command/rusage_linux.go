@@ -0,0 +1,20 @@
+//go:build linux
+// +build linux
+
+package command
+
+import (
+	"os"
+	"syscall"
+)
+
+// maxRSS returns the peak memory usage of the finished process in KB
+func maxRSS(ps *os.ProcessState) int64 {
+	if ps == nil {
+		return 0
+	}
+	if ru, ok := ps.SysUsage().(*syscall.Rusage); ok {
+		return ru.Maxrss
+	}
+	return 0
+}
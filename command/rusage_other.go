@@ -0,0 +1,11 @@
+//go:build !linux
+// +build !linux
+
+package command
+
+import "os"
+
+// maxRSS is not supported on this platform
+func maxRSS(ps *os.ProcessState) int64 {
+	return 0
+}
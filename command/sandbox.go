@@ -0,0 +1,78 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SandboxCommand is the hidden subcommand which runs a command inside the root filesystem
+const SandboxCommand = "_sandbox"
+
+// Sandbox is a root filesystem (an unpacked distro image) to compile and run the code in.
+// The working directory and Binds are bind-mounted at the same path inside RootFS.
+type Sandbox struct {
+	RootFS string
+	Binds  []string
+}
+
+// NewSandbox returns a Sandbox of the root filesystem directory.
+// binds are host directories visible inside the sandbox.
+func NewSandbox(rootfs string, binds ...string) (*Sandbox, error) {
+	root, err := filepath.Abs(rootfs)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := os.Stat(root)
+	if err != nil || !fi.IsDir() {
+		return nil, newMsgError("error.invalidRootfs", rootfs)
+	}
+	if isRootDir(fi) {
+		return nil, newMsgError("error.rootfsIsRoot", rootfs)
+	}
+
+	sb := &Sandbox{RootFS: root}
+	return sb.With(binds...)
+}
+
+// isRootDir reports whether fi is the root directory of the process.
+// The tmpfs can't hide the entries of the root directory, so it is rejected as rootfs.
+func isRootDir(fi os.FileInfo) bool {
+	rfi, err := os.Stat("/")
+	return err == nil && os.SameFile(fi, rfi)
+}
+
+// sandboxArgs parses the arguments of SandboxCommand
+// (rootfs, working directory, bind directories, "--" and the command)
+func sandboxArgs(args []string) (root, dir string, binds, com []string, err error) {
+	sep := -1
+	for n, arg := range args {
+		if arg == "--" {
+			sep = n
+			break
+		}
+	}
+	if sep < 2 || sep == len(args)-1 {
+		return "", "", nil, nil, fmt.Errorf("invalid arguments")
+	}
+	return args[0], args[1], args[2:sep], args[sep+1:], nil
+}
+
+// With returns a copy of the sandbox which also mounts dirs.
+// It returns nil if s is nil.
+func (s *Sandbox) With(dirs ...string) (*Sandbox, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	sb := &Sandbox{RootFS: s.RootFS, Binds: append([]string{}, s.Binds...)}
+	for _, d := range dirs {
+		abs, err := filepath.Abs(d)
+		if err != nil {
+			return nil, err
+		}
+		sb.Binds = append(sb.Binds, abs)
+	}
+	return sb, nil
+}
@@ -0,0 +1,211 @@
+//go:build linux
+// +build linux
+
+package command
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+)
+
+// Command returns the command which runs com in dir inside the root filesystem.
+// goyuki re-executes itself as SandboxCommand in new user and mount namespaces,
+// so no privilege is required.
+func (s *Sandbox) Command(dir string, com []string) (*exec.Cmd, error) {
+	args := []string{SandboxCommand, s.RootFS, dir}
+	args = append(args, s.Binds...)
+	args = append(args, "--")
+	args = append(args, com...)
+
+	cmd := exec.Command("/proc/self/exe", args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags: syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS,
+		UidMappings: []syscall.SysProcIDMap{
+			{ContainerID: 0, HostID: os.Getuid(), Size: 1},
+		},
+		GidMappings: []syscall.SysProcIDMap{
+			{ContainerID: 0, HostID: os.Getgid(), Size: 1},
+		},
+		GidMappingsEnableSetgroups: false,
+	}
+	return cmd, nil
+}
+
+// SandboxInit runs inside the namespaces created by Sandbox.Command.
+// args is rootfs, working directory, bind directories, "--" and the command.
+// It mounts the directories, changes root and executes the command.
+func SandboxInit(args []string) int {
+	if err := sandboxInit(args); err != nil {
+		fmt.Fprintf(os.Stderr, "sandbox: %v\n", err)
+	}
+	return ExitCodeFailed
+}
+
+func sandboxInit(args []string) error {
+	root, dir, binds, com, err := sandboxArgs(args)
+	if err != nil {
+		return err
+	}
+
+	if err := syscall.Mount("none", "/", "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("mount private: %v", err)
+	}
+	if err := sandboxRoot(root, append([]string{dir}, binds...)); err != nil {
+		return err
+	}
+
+	if err := syscall.Chroot(root); err != nil {
+		return fmt.Errorf("chroot: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		return err
+	}
+
+	p, err := exec.LookPath(com[0])
+	if err != nil {
+		return err
+	}
+	return syscall.Exec(p, com, os.Environ())
+}
+
+// sandboxRoot mounts a tmpfs over root which has the entries of the root filesystem and the bind directories.
+// The entries are bind-mounted from the root filesystem and the mount points of binds are made in the tmpfs,
+// so nothing is written to the root filesystem. All mounts disappear with the mount namespace.
+// root must not be the root directory of the process, whose path is not covered by a mount over it.
+func sandboxRoot(root string, binds []string) error {
+	// root and binds are opened before they are hidden by the tmpfs
+	src, err := os.Open(root)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	fi, err := src.Stat()
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() || isRootDir(fi) {
+		return fmt.Errorf("invalid rootfs: %s", root)
+	}
+
+	sources := map[string]string{}
+	for _, b := range binds {
+		f, err := os.Open(b)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		sources[b] = fdPath(f)
+	}
+
+	if err := syscall.Mount("tmpfs", root, "tmpfs", 0, "mode=0755"); err != nil {
+		return fmt.Errorf("mount tmpfs: %v", err)
+	}
+	if err := shadow(fdPath(src), root, binds); err != nil {
+		return err
+	}
+
+	// devices and process infomation are required by most compilers
+	for _, d := range []string{"/dev", "/proc"} {
+		if fi, err := os.Stat(filepath.Join(root, d)); err == nil && fi.IsDir() {
+			syscall.Mount(d, filepath.Join(root, d), "", syscall.MS_BIND|syscall.MS_REC, "")
+		}
+	}
+
+	// parent directories are mounted first
+	sort.Strings(binds)
+	for _, b := range binds {
+		target := filepath.Join(root, b)
+		if err := os.MkdirAll(target, DPerm); err != nil {
+			return err
+		}
+		if err := syscall.Mount(sources[b], target, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+			return fmt.Errorf("bind %s: %v", b, err)
+		}
+	}
+	return nil
+}
+
+// shadow bind-mounts the entries of the directory src into dst.
+// The directories on paths are made in dst and shadowed recursively instead,
+// so that the mount points of paths can be made under them.
+func shadow(src, dst string, paths []string) error {
+	next := map[string][]string{}
+	for _, p := range paths {
+		p = strings.TrimPrefix(filepath.Clean("/"+p), "/")
+		if p == "" {
+			continue
+		}
+
+		strs := strings.SplitN(p, "/", 2)
+		if len(strs) == 1 {
+			strs = append(strs, "")
+		}
+		next[strs[0]] = append(next[strs[0]], strs[1])
+	}
+
+	fis, err := ioutil.ReadDir(src)
+	if err != nil {
+		return err
+	}
+
+	for _, fi := range fis {
+		name := fi.Name()
+		s, d := filepath.Join(src, name), filepath.Join(dst, name)
+		rest, onPath := next[name]
+		delete(next, name)
+
+		switch {
+		case fi.Mode()&os.ModeSymlink != 0:
+			if onPath {
+				return fmt.Errorf("bind: %s is a symbolic link in rootfs", name)
+			}
+			link, err := os.Readlink(s)
+			if err != nil {
+				return err
+			}
+			if err := os.Symlink(link, d); err != nil {
+				return err
+			}
+		case fi.IsDir():
+			if err := os.Mkdir(d, fi.Mode().Perm()); err != nil {
+				return err
+			}
+			if onPath && !contains(rest, "") {
+				if err := shadow(s, d, rest); err != nil {
+					return err
+				}
+				continue
+			}
+			if onPath {
+				// the bind directory itself is mounted over it
+				continue
+			}
+			if err := syscall.Mount(s, d, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+				return fmt.Errorf("bind %s: %v", name, err)
+			}
+		default:
+			if onPath {
+				return fmt.Errorf("bind: %s is not a directory in rootfs", name)
+			}
+			if err := ioutil.WriteFile(d, nil, fi.Mode().Perm()); err != nil {
+				return err
+			}
+			if err := syscall.Mount(s, d, "", syscall.MS_BIND, ""); err != nil {
+				return fmt.Errorf("bind %s: %v", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// fdPath returns the path which refers to the opened file even if it is hidden by a mount
+func fdPath(f *os.File) string {
+	return fmt.Sprintf("/proc/self/fd/%d", f.Fd())
+}
@@ -0,0 +1,113 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestMain runs the sandbox init when the test binary is re-executed by Sandbox.Command
+func TestMain(m *testing.M) {
+	if len(os.Args) > 1 && os.Args[1] == SandboxCommand {
+		os.Exit(SandboxInit(os.Args[2:]))
+	}
+	os.Exit(m.Run())
+}
+
+// tmpRootFS makes a root filesystem which shares the programs of the host.
+// The directories of the host are returned as the binds and the symbolic links are copied.
+func tmpRootFS() (string, []string, error) {
+	root, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		return "", nil, err
+	}
+
+	var binds []string
+	for _, d := range []string{"/bin", "/lib", "/lib64", "/usr"} {
+		fi, err := os.Lstat(d)
+		switch {
+		case err != nil:
+			continue
+		case fi.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(d)
+			if err != nil {
+				return "", nil, err
+			}
+			if err := os.Symlink(link, filepath.Join(root, d)); err != nil {
+				return "", nil, err
+			}
+		case fi.IsDir():
+			binds = append(binds, d)
+		}
+	}
+	return root, binds, nil
+}
+
+func TestSandboxCommand(t *testing.T) {
+	root, binds, err := tmpRootFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	sb, err := NewSandbox(root, binds...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd, err := sb.Command(dir, []string{"sh", "-c", "echo $(pwd) > out.txt; ls /"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if strings.Contains(string(out), "sandbox:") {
+			t.Fatalf("sandbox error: %v\n%s", err, out)
+		}
+		t.Skipf("user namespaces are not available: %v\n%s", err, out)
+	}
+
+	if b, err := ioutil.ReadFile(filepath.Join(dir, "out.txt")); err != nil || strings.TrimSpace(string(b)) != dir {
+		t.Errorf("out.txt = %q, %v; want %s", b, err, dir)
+	}
+	// the host root directory is not visible
+	if strings.Contains(string(out), "home") {
+		t.Errorf("ls / = %s; want the entries of rootfs", out)
+	}
+
+	// the mount points are not left in rootfs
+	fis, err := ioutil.ReadDir(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fi := range fis {
+		if fi.Mode()&os.ModeSymlink == 0 {
+			t.Errorf("%s is created in rootfs", fi.Name())
+		}
+	}
+}
+
+func TestSandboxRootDir(t *testing.T) {
+	if _, err := NewSandbox("/"); err == nil {
+		t.Error("NewSandbox(/) = nil; want error")
+	}
+
+	cmd, err := (&Sandbox{RootFS: "/"}).Command("/tmp", []string{"true"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil && !strings.Contains(string(out), "sandbox:") {
+		t.Skipf("user namespaces are not available: %v\n%s", err, out)
+	}
+	if err == nil || !strings.Contains(string(out), "invalid rootfs") {
+		t.Errorf("sandbox with rootfs / = %v, %s; want invalid rootfs", err, out)
+	}
+}
@@ -0,0 +1,21 @@
+//go:build !linux
+// +build !linux
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// Command is not supported on this platform
+func (s *Sandbox) Command(dir string, com []string) (*exec.Cmd, error) {
+	return nil, fmt.Errorf("rootfs is supported on linux only")
+}
+
+// SandboxInit is not supported on this platform
+func SandboxInit(args []string) int {
+	fmt.Fprintln(os.Stderr, "sandbox: rootfs is supported on linux only")
+	return ExitCodeFailed
+}
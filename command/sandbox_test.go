@@ -0,0 +1,83 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNewSandbox(t *testing.T) {
+	root, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	sb, err := NewSandbox(root, "314")
+	if err != nil {
+		t.Fatalf("NewSandbox error: %v", err)
+	}
+
+	abs, _ := filepath.Abs("314")
+	if sb.RootFS != root || !reflect.DeepEqual(sb.Binds, []string{abs}) {
+		t.Errorf("NewSandbox = %+v", sb)
+	}
+
+	with, err := sb.With("/tmp/build")
+	if err != nil {
+		t.Fatalf("With error: %v", err)
+	}
+	if expected := []string{abs, "/tmp/build"}; !reflect.DeepEqual(with.Binds, expected) {
+		t.Errorf("With binds = %v, expected %v", with.Binds, expected)
+	}
+	if len(sb.Binds) != 1 {
+		t.Errorf("With modified the original sandbox: %v", sb.Binds)
+	}
+
+	if _, err := NewSandbox(filepath.Join(root, "none")); err == nil {
+		t.Errorf("NewSandbox should fail for missing rootfs")
+	}
+
+	var nilSB *Sandbox
+	if with, err := nilSB.With("/tmp"); with != nil || err != nil {
+		t.Errorf("nil With = %v, %v", with, err)
+	}
+}
+
+func TestSandboxArgs(t *testing.T) {
+	testCases := []struct {
+		args  []string
+		root  string
+		dir   string
+		binds []string
+		com   []string
+		err   bool
+	}{
+		{args: []string{"/rootfs", "/work", "--", "./a.out"}, root: "/rootfs", dir: "/work", binds: []string{}, com: []string{"./a.out"}},
+		{
+			args: []string{"/rootfs", "/work", "/tmp/a", "/tmp/b", "--", "g++", "-o", "a.out", "--", "main.cpp"},
+			root: "/rootfs", dir: "/work", binds: []string{"/tmp/a", "/tmp/b"}, com: []string{"g++", "-o", "a.out", "--", "main.cpp"},
+		},
+		{args: []string{}, err: true},
+		{args: []string{"/rootfs", "--", "./a.out"}, err: true},
+		{args: []string{"/rootfs", "/work", "--"}, err: true},
+		{args: []string{"/rootfs", "/work", "./a.out"}, err: true},
+	}
+
+	for _, testCase := range testCases {
+		root, dir, binds, com, err := sandboxArgs(testCase.args)
+		if testCase.err {
+			if err == nil {
+				t.Errorf("sandboxArgs(%q) should fail", testCase.args)
+			}
+			continue
+		}
+
+		if err != nil || root != testCase.root || dir != testCase.dir ||
+			!reflect.DeepEqual(binds, testCase.binds) || !reflect.DeepEqual(com, testCase.com) {
+			t.Errorf("sandboxArgs(%q) = %s, %s, %q, %q, %v", testCase.args, root, dir, binds, com, err)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mgutz/ansi"
+)
+
+// ShowCommand is a Command that shows the problem statement
+type ShowCommand struct {
+	Meta
+}
+
+var (
+	mdHeading = regexp.MustCompile(`^(#{1,6}) (.*)$`)
+	mdImage   = regexp.MustCompile(`!\[([^\]]*)\]\(([^)]*)\)`)
+	mdLink    = regexp.MustCompile(`\[([^\]]*)\]\(([^)]*)\)`)
+	mdBold    = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	mdCode    = regexp.MustCompile("`([^`]+)`")
+)
+
+// Run shows the statement saved by get command
+func (c *ShowCommand) Run(args []string) int {
+	var rawFlag bool
+
+	flags := c.Meta.NewFlagSet("show", c.Help())
+	flags.BoolVar(&rawFlag, "raw", false, "show Markdown as is")
+
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(c.msg("error.invalidOption", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+	args = flags.Args()
+
+	if len(args) < 1 {
+		c.UI.Error(c.msg("error.invalidArguments", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+
+	dir, err := ProblemDir(c.root(), args[0])
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, StatementMarkdown))
+	if os.IsNotExist(err) {
+		c.UI.Error(c.msg("show.noStatement", args[0]))
+		return ExitCodeFailed
+	}
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+
+	if rawFlag {
+		c.UI.Output(string(b))
+		return ExitCodeOK
+	}
+	c.UI.Output(renderMarkdown(string(b), dir, c.locale()))
+	return ExitCodeOK
+}
+
+// renderMarkdown decorates the statement for the terminal.
+// Images are shown as the path relative to the current directory.
+func renderMarkdown(md, dir, locale string) string {
+	var lines []string
+	code := false
+	for _, line := range strings.Split(strings.TrimRight(md, "\n"), "\n") {
+		if strings.HasPrefix(line, "```") {
+			code = !code
+			continue
+		}
+		if code {
+			lines = append(lines, "    "+ansi.Color(line, "cyan"))
+			continue
+		}
+
+		if m := mdHeading.FindStringSubmatch(line); m != nil {
+			style := "yellow+b"
+			if len(m[1]) <= 2 {
+				style = "yellow+bu"
+			}
+			lines = append(lines, ansi.Color(m[2], style))
+			continue
+		}
+
+		line = mdImage.ReplaceAllStringFunc(line, func(s string) string {
+			m := mdImage.FindStringSubmatch(s)
+			p := m[2]
+			if strings.HasPrefix(p, ImageDir+"/") {
+				p = filepath.Join(dir, filepath.FromSlash(p))
+			}
+			return ansi.Color(Message(locale, "show.image", p), "magenta")
+		})
+		line = mdLink.ReplaceAllString(line, "$1 ("+ansi.Color("$2", "blue")+")")
+		line = mdBold.ReplaceAllString(line, ansi.Color("$1", "white+b"))
+		line = mdCode.ReplaceAllString(line, ansi.Color("$1", "cyan"))
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Synopsis is a one-line, short synopsis of the command.
+func (c *ShowCommand) Synopsis() string {
+	return c.msg("show.synopsis")
+}
+
+// Help is a long-form help text
+func (c *ShowCommand) Help() string {
+	return strings.TrimSpace(c.msg("show.help"))
+}
@@ -0,0 +1,72 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestShowCommand_implement(t *testing.T) {
+	var _ cli.Command = &ShowCommand{}
+}
+
+func TestShowCommand(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	md := "### No.1\n\n**太字** と `code` と [link](https://example.com)\n\n![図](images/a.png)\n\n```\n1 2\n```\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, StatementMarkdown), []byte(md), FPerm); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		args   []string
+		code   int
+		result string
+	}{
+		{args: []string{"-foo"}, code: ExitCodeFailed, result: "不正なオプション"},
+		{args: []string{}, code: ExitCodeFailed, result: "不正な引数"},
+		{args: []string{"testdata/337"}, code: ExitCodeFailed, result: "問題文がありません"},
+	}
+
+	for _, testCase := range testCases {
+		ui := new(cli.MockUi)
+		c := &ShowCommand{Meta: Meta{UI: ui}}
+
+		code := c.Run(testCase.args)
+		errs := ui.ErrorWriter.String()
+		if code != testCase.code || !strings.Contains(errs, testCase.result) {
+			t.Errorf("bad status code = %v; want %v\nError message = %s; want %s", code, testCase.code, errs, testCase.result)
+		}
+	}
+
+	ui := new(cli.MockUi)
+	c := &ShowCommand{Meta: Meta{UI: ui}}
+	if code := c.Run([]string{"-raw", dir}); code != ExitCodeOK || ui.OutputWriter.String() != md+"\n" {
+		t.Errorf("raw output = %q", ui.OutputWriter.String())
+	}
+
+	ui = new(cli.MockUi)
+	c = &ShowCommand{Meta: Meta{UI: ui}}
+	if code := c.Run([]string{dir}); code != ExitCodeOK {
+		t.Fatalf("bad status code = %v; want %v", code, ExitCodeOK)
+	}
+	out := ui.OutputWriter.String()
+	for _, s := range []string{"No.1", "太字", "link (", "[画像: " + filepath.Join(dir, "images", "a.png") + "]", "    "} {
+		if !strings.Contains(out, s) {
+			t.Errorf("output does not contain %q\n%s", s, out)
+		}
+	}
+	for _, s := range []string{"###", "**", "```", "`"} {
+		if strings.Contains(out, s) {
+			t.Errorf("output contains markdown %q\n%s", s, out)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// StatementMarkdown is the problem statement file in the problem directory
+const StatementMarkdown = "statement.md"
+
+// ImageDir is the directory of the images in the problem statement
+const ImageDir = "images"
+
+// MaxImageSize is the size limit of an image in the problem statement
+var MaxImageSize int64 = 10 << 20
+
+var (
+	blankLines = regexp.MustCompile(`\n{3,}`)
+	spaces     = regexp.MustCompile(`\s+`)
+)
+
+// statementFiles converts the problem page into Markdown and downloads the images.
+// Math is kept as is so that it can be read as LaTeX.
+// Images which can't be downloaded are linked to the original url.
+func statementFiles(client *HTTPClient, page []byte, pageURL string) (map[string][]byte, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(page))
+	if err != nil {
+		return nil, fmt.Errorf("statement parse error: %v", err)
+	}
+
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &markdown{base: base, images: map[string]string{}}
+	md := m.convert(statementRoot(doc))
+
+	files := map[string][]byte{}
+	for name, src := range m.images {
+		b, err := downloadImage(client, src)
+		if err != nil {
+			md = strings.Replace(md, "]("+path.Join(ImageDir, name)+")", "]("+src+")", -1)
+			continue
+		}
+		files[path.Join(ImageDir, name)] = b
+	}
+	files[StatementMarkdown] = []byte(md)
+	return files, nil
+}
+
+// statementRoot returns the element containing the problem statement
+func statementRoot(doc *goquery.Document) *goquery.Selection {
+	if s := doc.Find("#task-statement"); s.Size() > 0 {
+		if ja := s.Find("span.lang-ja"); ja.Size() > 0 {
+			return ja.First()
+		}
+		return s.First()
+	}
+	if s := doc.Find("div#content"); s.Size() > 0 {
+		return s.First()
+	}
+	if s := doc.Find("div.problem-statement"); s.Size() > 0 {
+		return s.First()
+	}
+	return doc.Find("body")
+}
+
+func downloadImage(client *HTTPClient, src string) ([]byte, error) {
+	res, err := client.Get(src)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("image error: %s", res.Status)
+	}
+
+	b, err := ioutil.ReadAll(io.LimitReader(res.Body, MaxImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(b)) > MaxImageSize {
+		return nil, fmt.Errorf("image too large: %s", src)
+	}
+	return b, nil
+}
+
+// markdown converts HTML elements into Markdown
+type markdown struct {
+	base *url.URL
+
+	// images maps the local file name to the image url
+	images map[string]string
+}
+
+func (m *markdown) convert(s *goquery.Selection) string {
+	md := blankLines.ReplaceAllString(m.children(s), "\n\n")
+
+	var lines []string
+	code := false
+	for _, line := range strings.Split(md, "\n") {
+		if strings.HasPrefix(line, "```") {
+			code = !code
+		}
+		if !code {
+			line = strings.TrimRight(line, " \t")
+			if strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "  ") {
+				line = line[1:]
+			}
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")) + "\n"
+}
+
+func (m *markdown) children(s *goquery.Selection) string {
+	var buf bytes.Buffer
+	s.Contents().Each(func(n int, c *goquery.Selection) {
+		buf.WriteString(m.node(c))
+	})
+	return buf.String()
+}
+
+func block(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ""
+	}
+	return "\n\n" + s + "\n\n"
+}
+
+func (m *markdown) node(s *goquery.Selection) string {
+	switch name := goquery.NodeName(s); name {
+	case "#text":
+		return spaces.ReplaceAllString(s.Text(), " ")
+	case "#comment", "script", "style", "form", "button", "noscript", "input", "select":
+		return ""
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level := int(name[1] - '0')
+		return block(strings.Repeat("#", level) + " " + strings.TrimSpace(m.children(s)))
+	case "pre":
+		return "\n\n```\n" + strings.Trim(s.Text(), "\n") + "\n```\n\n"
+	case "code":
+		return "`" + s.Text() + "`"
+	case "var":
+		return "$" + s.Text() + "$"
+	case "br":
+		return "\n"
+	case "hr":
+		return block("---")
+	case "strong", "b":
+		if t := strings.TrimSpace(m.children(s)); t != "" {
+			return "**" + t + "**"
+		}
+		return ""
+	case "em", "i":
+		if s.HasClass("fa-star") {
+			return "★"
+		}
+		if t := strings.TrimSpace(m.children(s)); t != "" {
+			return "*" + t + "*"
+		}
+		return ""
+	case "a":
+		text := strings.TrimSpace(m.children(s))
+		href, _ := s.Attr("href")
+		if text == "" || href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(href, "javascript:") {
+			return text
+		}
+		return "[" + text + "](" + m.resolve(href) + ")"
+	case "img":
+		src, _ := s.Attr("src")
+		alt, _ := s.Attr("alt")
+		return "![" + alt + "](" + m.image(src) + ")"
+	case "ul", "ol":
+		return block(m.list(s, name == "ol"))
+	case "table":
+		return block(m.table(s))
+	case "p", "div", "section", "blockquote", "dl", "dd", "dt":
+		return block(m.children(s))
+	}
+	return m.children(s)
+}
+
+func (m *markdown) list(s *goquery.Selection, ordered bool) string {
+	var items []string
+	s.ChildrenFiltered("li").Each(func(n int, li *goquery.Selection) {
+		marker := "- "
+		if ordered {
+			marker = fmt.Sprintf("%d. ", n+1)
+		}
+		indent := "\n" + strings.Repeat(" ", len(marker))
+
+		text := blankLines.ReplaceAllString(strings.TrimSpace(m.children(li)), "\n\n")
+		items = append(items, marker+strings.Replace(text, "\n", indent, -1))
+	})
+	return strings.Join(items, "\n")
+}
+
+func (m *markdown) table(s *goquery.Selection) string {
+	var rows []string
+	s.Find("tr").Each(func(n int, tr *goquery.Selection) {
+		var cells []string
+		tr.Children().Each(func(_ int, c *goquery.Selection) {
+			text := spaces.ReplaceAllString(strings.TrimSpace(m.children(c)), " ")
+			cells = append(cells, strings.Replace(text, "|", "\\|", -1))
+		})
+		rows = append(rows, "| "+strings.Join(cells, " | ")+" |")
+		if n == 0 {
+			rows = append(rows, "|"+strings.Repeat(" --- |", len(cells)))
+		}
+	})
+	return strings.Join(rows, "\n")
+}
+
+// resolve returns the absolute url of the link
+func (m *markdown) resolve(href string) string {
+	u, err := m.base.Parse(href)
+	if err != nil {
+		return href
+	}
+	return u.String()
+}
+
+// image returns the local path of the image and registers it for download
+func (m *markdown) image(src string) string {
+	u, err := m.base.Parse(src)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return src
+	}
+
+	abs := u.String()
+	base := path.Base(u.Path)
+	if base == "/" || base == "." || base == ".." {
+		base = "image"
+	}
+
+	name := base
+	for n := 1; ; n++ {
+		if cur, ok := m.images[name]; !ok || cur == abs {
+			break
+		}
+		name = fmt.Sprintf("%d_%s", n, base)
+	}
+	m.images[name] = abs
+	return path.Join(ImageDir, name)
+}
@@ -0,0 +1,84 @@
+package command
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestStatementFiles(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/img/figure.png" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("png"))
+	}))
+	defer ts.Close()
+
+	page := fmt.Sprintf(`<html><body><div id="content">
+<h3>No.1 足し算</h3>
+<p>レベル : <i class="fa fa-star"></i><i class="fa fa-star"></i></p>
+<div class="block">
+  <h4>問題文</h4>
+  <p>整数 $A$, $B$ が与えられる。<strong>$A+B$</strong> を出力せよ。</p>
+  <ul><li>$1 \le A \le 10^9$</li><li>詳しくは<a href="/wiki">こちら</a></li></ul>
+  <img src="%[1]s/img/figure.png" alt="図">
+  <img src="%[1]s/img/missing.png" alt="なし">
+  <table><tr><th>入力</th><th>出力</th></tr><tr><td>1 2</td><td>3</td></tr></table>
+  <script>alert(1)</script>
+</div>
+<div class="sample"><h5>サンプル1</h5><pre>1 2
+</pre><pre>3
+</pre></div>
+</div></body></html>`, ts.URL)
+
+	files, err := statementFiles(DefaultHTTPClient, []byte(page), "https://yukicoder.me/problems/no/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	md := string(files[StatementMarkdown])
+	expected := []string{
+		"### No.1 足し算",
+		"レベル : ★★",
+		"#### 問題文",
+		"整数 $A$, $B$ が与えられる。**$A+B$** を出力せよ。",
+		"- $1 \\le A \\le 10^9$",
+		"- 詳しくは[こちら](https://yukicoder.me/wiki)",
+		"![図](images/figure.png)",
+		"![なし](" + ts.URL + "/img/missing.png)",
+		"| 入力 | 出力 |\n| --- | --- |\n| 1 2 | 3 |",
+		"```\n1 2\n```",
+	}
+	for _, s := range expected {
+		if !strings.Contains(md, s) {
+			t.Errorf("statement does not contain %q\n%s", s, md)
+		}
+	}
+	if strings.Contains(md, "alert") {
+		t.Errorf("statement contains script\n%s", md)
+	}
+
+	if string(files["images/figure.png"]) != "png" || len(files) != 2 {
+		t.Errorf("statement files = %v", files)
+	}
+}
+
+func TestStatementFilesAtCoder(t *testing.T) {
+	page := `<html><body><div id="task-statement"><span class="lang"><span class="lang-ja">
+<div class="part"><section><h3>問題文</h3><p><var>N</var> を出力せよ。</p></section></div>
+</span><span class="lang-en"><p>English</p></span></span></div></body></html>`
+
+	files, err := statementFiles(DefaultHTTPClient, []byte(page), "https://atcoder.jp/contests/abc1/tasks/abc1_a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	md := string(files[StatementMarkdown])
+	if md != "### 問題文\n\n$N$ を出力せよ。\n" {
+		t.Errorf("statement = %q", md)
+	}
+}
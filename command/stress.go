@@ -0,0 +1,228 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// StressCommand is a Command that compares the solution with a brute-force one on random inputs
+type StressCommand struct {
+	Meta
+}
+
+// StressPrefix is name prefix of the counterexample saved by stress command
+const StressPrefix = "stress_"
+
+// Run run the stress test
+func (c *StressCommand) Run(args []string) int {
+	var (
+		langFlag      string
+		bruteFlag     string
+		genFlag       string
+		validaterFlag string
+		verboseFlag   bool
+		roundFlag     int
+		countFlag     int
+		seedFlag      int
+	)
+
+	flags := c.Meta.NewFlagSet("stress", c.Help())
+	flags.StringVar(&langFlag, "l", "", "Specify Language")
+	flags.StringVar(&langFlag, "language", "", "Specify Language")
+	flags.StringVar(&bruteFlag, "b", "", "Specify brute-force solution")
+	flags.StringVar(&bruteFlag, "brute", "", "Specify brute-force solution")
+	flags.StringVar(&genFlag, "g", "", "Specify input generator")
+	flags.StringVar(&genFlag, "gen", "", "Specify input generator")
+	flags.StringVar(&validaterFlag, "V", "diff", "Specify Validater")
+	flags.StringVar(&validaterFlag, "validater", "diff", "Specify Validater")
+	flags.BoolVar(&verboseFlag, "vb", false, "increase amount of output")
+	flags.BoolVar(&verboseFlag, "verbose", false, "increase amount of output")
+	flags.IntVar(&roundFlag, "p", 0, "Rounded to the decimal point p digits")
+	flags.IntVar(&roundFlag, "place", 0, "Rounded to the decimal point place digits")
+	flags.IntVar(&countFlag, "n", 1000, "Number of iterations")
+	flags.IntVar(&seedFlag, "seed", 1, "First seed passed to the generator")
+
+	rest, err := ParseInterspersed(flags, args)
+	if err != nil {
+		c.UI.Error(c.msg("error.invalidOption", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+	args = rest
+
+	if len(args) < 2 || bruteFlag == "" || genFlag == "" {
+		c.UI.Error(c.msg("error.invalidArguments", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+
+	dir, err := ProblemDir(c.root(), args[0])
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+	args[0] = dir
+
+	if _, err := os.Stat(args[0]); err != nil {
+		c.UI.Error(c.msg("error.noDirectory"))
+		return ExitCodeFailed
+	}
+
+	lang, err := sourceLang(args[1], langFlag)
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+
+	bLang, err := sourceLang(bruteFlag, "")
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+
+	gLang, err := sourceLang(genFlag, "")
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+
+	if countFlag < 0 {
+		c.UI.Error(c.msg("stress.invalidCount", countFlag))
+		return ExitCodeFailed
+	}
+
+	v, err := NewValidater(validaterFlag, roundFlag)
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+
+	info, err := ReadInfo(args[0])
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+
+	if info.JudgeType > 0 {
+		c.UI.Error(c.msg("stress.normalOnly"))
+		return ExitCodeFailed
+	}
+
+	var w, e io.Writer
+	if verboseFlag {
+		w, e = os.Stdout, os.Stderr
+	}
+
+	code, result, clearFunc, err := NewCode(args[1], lang, info, nil, w, e)
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+	c.UI.Output(result.String())
+	defer clearFunc()
+
+	brute, _, clearFunc, err := NewCode(bruteFlag, bLang, info, nil, w, e)
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+	defer clearFunc()
+
+	gen, _, clearFunc, err := NewCode(genFlag, gLang, info, nil, w, e)
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+	defer clearFunc()
+
+	for i := 0; countFlag == 0 || i < countFlag; i++ {
+		seed := seedFlag + i
+
+		input, err := gen.Output(nil, e, fmt.Sprint(seed))
+		if err != nil {
+			c.UI.Error(c.msg("stress.generator", err))
+			return ExitCodeFailed
+		}
+
+		expected, err := brute.Output(bytes.NewReader(input), e)
+		if err != nil {
+			c.UI.Error(c.msg("stress.bruteForce", err))
+			return ExitCodeFailed
+		}
+
+		var buf bytes.Buffer
+		result, err := code.Run(v, expected, bytes.NewReader(input), &buf, e)
+		if err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+		c.UI.Output(c.msg("stress.result", result, seed))
+
+		if strings.HasPrefix(result, AC) {
+			continue
+		}
+
+		name, err := saveTestCase(args[0], fmt.Sprintf("%s%d", StressPrefix, seed), input, expected)
+		if err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+		c.UI.Info(c.msg("stress.saved", name))
+		return ExitCodeFailed
+	}
+	return ExitCodeOK
+}
+
+// Synopsis is a one-line, short synopsis of the command.
+func (c *StressCommand) Synopsis() string {
+	return c.msg("stress.synopsis")
+}
+
+// Help is a long-form help text
+func (c *StressCommand) Help() string {
+	return strings.TrimSpace(c.msg("stress.help"))
+}
+
+// sourceLang returns the Lang entry of the key, or of the source file extension if key is empty
+func sourceLang(source, key string) ([]string, error) {
+	if key == "" {
+		key = strings.Replace(path.Ext(source), ".", "", -1)
+	}
+
+	lang, ok := Lang[key]
+	if !ok {
+		return nil, newMsgError("error.invalidLanguage", key)
+	}
+	return lang, nil
+}
+
+// saveTestCase writes a new input/output pair into the problem directory and returns its name
+func saveTestCase(dir, name string, input, output []byte) (string, error) {
+	file := name + ".txt"
+	for n := 1; ; n++ {
+		_, err1 := os.Stat(filepath.Join(dir, InputDir, file))
+		_, err2 := os.Stat(filepath.Join(dir, OutputDir, file))
+		if os.IsNotExist(err1) && os.IsNotExist(err2) {
+			break
+		}
+		file = fmt.Sprintf("%s_%d.txt", name, n)
+	}
+
+	for _, d := range []string{InputDir, OutputDir} {
+		if err := os.MkdirAll(filepath.Join(dir, d), DPerm); err != nil {
+			return "", err
+		}
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, InputDir, file), input, FPerm); err != nil {
+		return "", err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, OutputDir, file), output, FPerm); err != nil {
+		return "", err
+	}
+	return file, nil
+}
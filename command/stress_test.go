@@ -0,0 +1,112 @@
+package command
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestStressCommand_implement(t *testing.T) {
+	var _ cli.Command = &StressCommand{}
+}
+
+func TestStressCommandFlag(t *testing.T) {
+	testCases := []struct {
+		args   []string
+		code   int
+		result string
+	}{
+		{args: []string{"-foo", "lang", "-hoge"}, code: ExitCodeFailed, result: "不正なオプション"},
+		{args: []string{}, code: ExitCodeFailed, result: "不正な引数"},
+		{args: []string{"testdata/337", "foo.go", "-brute", "bar.py"}, code: ExitCodeFailed, result: "不正な引数"},
+		{args: []string{"testdata/337", "foo.go", "-brute", "bar.hoge", "-gen", "gen.py"}, code: ExitCodeFailed, result: "不正な言語です: hoge"},
+		{args: []string{"-l", "lang", "testdata/337", "foo.go", "-b", "bar.py", "-g", "gen.py"}, code: ExitCodeFailed, result: "不正な言語"},
+		{args: []string{"testdata/337", "foo.go", "-b", "bar.py", "-g", "gen.py", "-V", "hoge"}, code: ExitCodeFailed, result: "不正なバリデータ"},
+		{args: []string{"testdata/337", "foo.go", "-b", "bar.py", "-g", "gen.py", "-V", "float", "-p", "16"}, code: ExitCodeFailed, result: "不正な桁数"},
+		{args: []string{"testdata/337", "foo.go", "-b", "bar.py", "-g", "gen.py", "-n", "-1"}, code: ExitCodeFailed, result: "不正な回数"},
+	}
+
+	for _, testCase := range testCases {
+		ui := new(cli.MockUi)
+		c := &StressCommand{
+			Meta: Meta{
+				UI: ui,
+			},
+		}
+
+		code := c.Run(testCase.args)
+		errs := ui.ErrorWriter.String()
+
+		if code != testCase.code || !strings.Contains(errs, testCase.result) {
+			t.Errorf("bad status code = %v; want %v\nError message = %s; want %s", code, testCase.code, errs, testCase.result)
+		}
+	}
+}
+
+func TestStressCommandRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	b, err := json.Marshal(&Info{No: "17", Time: 1, Mem: 256})
+	if err != nil {
+		t.Fatal(err)
+	}
+	scripts := map[string]string{
+		InfoFile:   string(b),
+		"gen.sh":   "echo $1\n",
+		"loop.sh":  "while :; do :; done\n",
+		"brute.sh": "read n\necho $((n * 2))\n",
+		"fast.sh":  "read n\nif [ $n -eq 3 ]; then echo 0; else echo $((n * 2)); fi\n",
+	}
+	for name, s := range scripts {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(s), FPerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	script := func(name string) string {
+		return filepath.Join(dir, name)
+	}
+
+	testCases := []struct {
+		args   []string
+		code   int
+		result string
+	}{
+		{args: []string{dir, script("fast.sh"), "-b", script("brute.sh"), "-g", script("gen.sh"), "-n", "2"}, code: ExitCodeOK},
+		{args: []string{dir, script("fast.sh"), "-b", script("brute.sh"), "-g", script("gen.sh"), "-n", "5"}, code: ExitCodeFailed, result: "stress_3.txt"},
+		{args: []string{dir, script("fast.sh"), "-b", script("brute.sh"), "-g", script("loop.sh")}, code: ExitCodeFailed, result: "ジェネレータのエラー"},
+		{args: []string{dir, script("fast.sh"), "-b", script("loop.sh"), "-g", script("gen.sh")}, code: ExitCodeFailed, result: "愚直解のエラー"},
+	}
+
+	for _, testCase := range testCases {
+		ui := cli.NewMockUi()
+		c := &StressCommand{
+			Meta: Meta{
+				UI: ui,
+			},
+		}
+
+		code := c.Run(testCase.args)
+		out := ui.OutputWriter.String() + ui.ErrorWriter.String()
+		if code != testCase.code || !strings.Contains(out, testCase.result) {
+			t.Errorf("Run(%v) = %v; want %v\noutput = %s; want %s", testCase.args, code, testCase.code, out, testCase.result)
+		}
+	}
+
+	for d, want := range map[string]string{InputDir: "3\n", OutputDir: "6\n"} {
+		if b, err := ioutil.ReadFile(filepath.Join(dir, d, StressPrefix+"3.txt")); err != nil || string(b) != want {
+			t.Errorf("%s/%s3.txt = %q, %v; want %q", d, StressPrefix, b, err, want)
+		}
+	}
+	if fis, _ := ioutil.ReadDir(filepath.Join(dir, InputDir)); len(fis) != 1 {
+		t.Errorf("%d counterexamples; want 1", len(fis))
+	}
+}
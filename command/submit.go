@@ -0,0 +1,160 @@
+package command
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SubmitCommand is a Command that submit the source to yukicoder
+type SubmitCommand struct {
+	Meta
+
+	// API is used instead of yukicoder API if it is not nil
+	API *APIClient
+}
+
+// Run submit the source and wait for the judge
+func (c *SubmitCommand) Run(args []string) int {
+	var (
+		langFlag     string
+		intervalFlag time.Duration
+		timeoutFlag  time.Duration
+	)
+
+	flags := c.Meta.NewFlagSet("submit", c.Help())
+	flags.StringVar(&langFlag, "l", "", "Specify Language")
+	flags.StringVar(&langFlag, "language", "", "Specify Language")
+	flags.DurationVar(&intervalFlag, "interval", 2*time.Second, "Polling interval")
+	flags.DurationVar(&timeoutFlag, "timeout", 5*time.Minute, "Time to wait for the judge")
+
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(c.msg("error.invalidOption", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+	args = flags.Args()
+
+	if len(args) < 2 {
+		c.UI.Error(c.msg("error.invalidArguments", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+
+	if langFlag == "" {
+		langFlag = strings.Replace(path.Ext(args[1]), ".", "", -1)
+	}
+	if _, ok := SubmitLang[langFlag]; !ok {
+		c.UI.Error(c.msg("error.invalidLanguage", langFlag))
+		return ExitCodeFailed
+	}
+
+	api := c.API
+	if api == nil {
+		cred, err := LoadCredential()
+		if err != nil {
+			c.UI.Error(c.errMsg(err))
+			return ExitCodeFailed
+		}
+		// the submission and the judge result are only available through API
+		if cred.Token == "" && cred.Cookie != "" {
+			c.UI.Error(c.msg("submit.cookieOnly"))
+			return ExitCodeFailed
+		}
+		if cred.Token == "" {
+			c.UI.Error(c.msg("submit.noToken"))
+			return ExitCodeFailed
+		}
+		api = NewAPIClient(cred.Token)
+	}
+	y := &Yukicoder{UI: c.UI, API: api}
+
+	id, err := submitProblem(y, args[0])
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+
+	source, err := ioutil.ReadFile(args[1])
+	if err != nil {
+		c.UI.Error(c.msg("submit.readSource", err))
+		return ExitCodeFailed
+	}
+
+	var s Submitter = y
+	sid, err := s.Submit(id, langFlag, source)
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+
+	n, err := strconv.Atoi(sid)
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+	c.UI.Info(c.msg("submit.submitted", strings.TrimSuffix(BaseURL, "/problems"), n))
+
+	sub, err := waitJudge(api, n, intervalFlag, timeoutFlag)
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+
+	strs := make([]string, 4)
+	strs[0] = c.msg("submission.problem", id)
+	strs[1] = c.msg("submission.id", sub.ID)
+	strs[2] = c.msg("submission.language", sub.Language)
+	strs[3] = c.msg("submission.result", Verdict(sub.Result))
+	c.UI.Output(strings.Join(strs, "\n"))
+
+	for _, tc := range sub.TestCases {
+		c.UI.Output(fmt.Sprintf("%s: %d ms\t%s", Verdict(tc.Result), tc.Time, tc.Name))
+	}
+	return ExitCodeOK
+}
+
+// Synopsis is a one-line, short synopsis of the command.
+func (c *SubmitCommand) Synopsis() string {
+	return c.msg("submit.synopsis")
+}
+
+// Help is a long-form help text
+func (c *SubmitCommand) Help() string {
+	return strings.TrimSpace(c.msg("submit.help"))
+}
+
+// submitProblem returns the problem number from a problem number, url or directory
+func submitProblem(y *Yukicoder, spec string) (string, error) {
+	for _, s := range []string{spec, filepath.Base(filepath.Clean(spec))} {
+		id, ok, err := y.Parse(s)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return id, nil
+		}
+	}
+	return "", newMsgError("error.unsupportedProblem", spec)
+}
+
+// waitJudge polls the submission until it is judged
+func waitJudge(api *APIClient, id int, interval, timeout time.Duration) (*APISubmission, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		sub, err := api.Submission(id)
+		if err != nil {
+			return nil, err
+		}
+		if !sub.Judging() {
+			return sub, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("judge timed out: submission %d", id)
+		}
+		time.Sleep(interval)
+	}
+}
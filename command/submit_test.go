@@ -0,0 +1,151 @@
+package command
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestSubmitCommand_implement(t *testing.T) {
+	var _ cli.Command = &SubmitCommand{}
+	var _ Submitter = &Yukicoder{}
+}
+
+func TestSubmitCommandFlag(t *testing.T) {
+	testCases := []struct {
+		args   []string
+		code   int
+		result string
+	}{
+		{args: []string{"-foo", "lang", "-hoge"}, code: ExitCodeFailed, result: "不正なオプション"},
+		{args: []string{"1"}, code: ExitCodeFailed, result: "不正な引数"},
+		{args: []string{"-l", "lang", "1", "foo.go"}, code: ExitCodeFailed, result: "不正な言語"},
+		{args: []string{"1", "foo.go"}, code: ExitCodeFailed, result: "$GOYUKI_TOKENが設定されていません: goyuki loginを実行してください"},
+	}
+
+	clearFunc := setEnv("GOYUKI_TOKEN", "")
+	defer clearFunc()
+	defer setEnv("GOYUKI", "")()
+	_, clearConfig, err := tmpConfigDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clearConfig()
+
+	for _, testCase := range testCases {
+		ui := new(cli.MockUi)
+		c := &SubmitCommand{
+			Meta: Meta{
+				UI: ui,
+			},
+		}
+
+		code := c.Run(testCase.args)
+		errs := ui.ErrorWriter.String()
+
+		if code != testCase.code || !strings.Contains(errs, testCase.result) {
+			t.Errorf("bad status code = %v; want %v\nError message = %s; want %s", code, testCase.code, errs, testCase.result)
+		}
+	}
+}
+
+func TestSubmitCommandCookieOnly(t *testing.T) {
+	_, clearConfig, err := tmpConfigDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clearConfig()
+	defer setEnv("GOYUKI_TOKEN", "")()
+	defer setEnv("GOYUKI", "session")()
+
+	ui := new(cli.MockUi)
+	c := &SubmitCommand{Meta: Meta{UI: ui}}
+
+	result := "REVEL_SESSION cookieは使用できません"
+	code := c.Run([]string{"1", "foo.go"})
+	if errs := ui.ErrorWriter.String(); code != ExitCodeFailed || !strings.Contains(errs, result) {
+		t.Errorf("bad status code = %v; want %v\nError message = %s; want %s", code, ExitCodeFailed, errs, result)
+	}
+}
+
+func TestSubmitCommand(t *testing.T) {
+	polled := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/problems/no/1/submit":
+			if r.Method != "POST" || r.FormValue("lang") != "cpp" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			f, _, err := r.FormFile("file")
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			defer f.Close()
+			if b, _ := ioutil.ReadAll(f); string(b) != "int main(){}" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"SubmissionId":5}`)
+		case "/submissions/5":
+			polled++
+			if polled < 2 {
+				fmt.Fprint(w, `{"Id":5,"Result":"WJ"}`)
+				return
+			}
+			fmt.Fprint(w, `{"Id":5,"Language":"C++14","Result":"WA","TestCaseResults":[
+				{"Name":"1.txt","Result":"AC","Time":12},
+				{"Name":"2.txt","Result":"WA","Time":34}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	source := filepath.Join(dir, "main.cpp")
+	if err := ioutil.WriteFile(source, []byte("int main(){}"), FPerm); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := new(cli.MockUi)
+	c := &SubmitCommand{
+		Meta: Meta{
+			UI: ui,
+		},
+		API: &APIClient{URL: ts.URL, Token: "secret"},
+	}
+
+	code := c.Run([]string{"-interval", "1ms", "https://yukicoder.me/problems/no/1", source})
+	if code != ExitCodeOK {
+		t.Fatalf("bad status code = %v; want %v\nError message = %s", code, ExitCodeOK, ui.ErrorWriter.String())
+	}
+
+	out := ui.OutputWriter.String()
+	for _, want := range []string{AC + ": 12 ms\t1.txt", WA + ": 34 ms\t2.txt", "C++14"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output = %s; want %s", out, want)
+		}
+	}
+	if polled != 2 {
+		t.Errorf("polled %d times; want 2", polled)
+	}
+}
@@ -0,0 +1,186 @@
+package command
+
+import (
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Testcase directories
+const (
+	InputDir  = "test_in"
+	OutputDir = "test_out"
+)
+
+// CustomPrefix is name prefix of the testcase added by user
+const CustomPrefix = "custom_"
+
+// testcaseExts is suffixes stripped from file names when pairing
+var testcaseExts = []string{".in", ".out", ".txt"}
+
+// TestCase is a pair of input and expected output file
+type TestCase struct {
+	Name   string
+	In     string
+	Out    string
+	Custom bool
+}
+
+// isCaseFile reports whether the slash-separated path is directly under test_in or test_out
+func isCaseFile(name string) bool {
+	d := path.Dir(name)
+	return d == InputDir || d == OutputDir
+}
+
+// TestCases pairs the files of test_in and test_out by base name.
+// It returns the cases in natural order and warnings about unmatched files.
+func TestCases(dir string) ([]*TestCase, []error, error) {
+	inputs, inWarnings, err := caseFiles(filepath.Join(dir, InputDir))
+	if err != nil {
+		return nil, nil, newMsgError("testcase.input", err)
+	}
+
+	outputs, outWarnings, err := caseFiles(filepath.Join(dir, OutputDir))
+	if err != nil {
+		return nil, nil, newMsgError("testcase.output", err)
+	}
+
+	var cases []*TestCase
+	warnings := append(inWarnings, outWarnings...)
+	for name, in := range inputs {
+		out, ok := outputs[name]
+		if !ok {
+			warnings = append(warnings, newMsgError("testcase.noOutput", in))
+			continue
+		}
+		cases = append(cases, &TestCase{
+			Name:   filepath.Base(in),
+			In:     in,
+			Out:    out,
+			Custom: IsCustom(name),
+		})
+	}
+
+	for name, out := range outputs {
+		if _, ok := inputs[name]; !ok {
+			warnings = append(warnings, newMsgError("testcase.noInput", out))
+		}
+	}
+
+	sort.Sort(byName(cases))
+	sort.Sort(byMessage(warnings))
+	return cases, warnings, nil
+}
+
+// IsCustom reports whether the testcase was added by user (add or stress command)
+func IsCustom(name string) bool {
+	return strings.HasPrefix(name, CustomPrefix) || strings.HasPrefix(name, StressPrefix)
+}
+
+// SplitCustom splits cases into downloaded and custom ones
+func SplitCustom(cases []*TestCase) (official, custom []*TestCase) {
+	for _, tc := range cases {
+		if tc.Custom {
+			custom = append(custom, tc)
+		} else {
+			official = append(official, tc)
+		}
+	}
+	return official, custom
+}
+
+// caseFiles returns regular files of dir keyed by case name.
+// If several files have the same case name (case1.in and case1.txt), the first one in name order is used
+// and the others are returned as warnings.
+func caseFiles(dir string) (map[string]string, []error, error) {
+	files := map[string]string{}
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var warnings []error
+	for _, fi := range fis {
+		if fi.IsDir() || strings.HasPrefix(fi.Name(), ".") {
+			continue
+		}
+
+		p := filepath.Join(dir, fi.Name())
+		name := caseName(fi.Name())
+		if used, ok := files[name]; ok {
+			warnings = append(warnings, newMsgError("testcase.duplicate", p, used))
+			continue
+		}
+		files[name] = p
+	}
+	return files, warnings, nil
+}
+
+// caseName strips the testcase suffix from the file name
+func caseName(file string) string {
+	for _, ext := range testcaseExts {
+		if strings.HasSuffix(file, ext) && len(file) > len(ext) {
+			return strings.TrimSuffix(file, ext)
+		}
+	}
+	return file
+}
+
+type byName []*TestCase
+
+func (b byName) Len() int           { return len(b) }
+func (b byName) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byName) Less(i, j int) bool { return NaturalLess(b[i].Name, b[j].Name) }
+
+type naturalStrings []string
+
+func (n naturalStrings) Len() int           { return len(n) }
+func (n naturalStrings) Swap(i, j int)      { n[i], n[j] = n[j], n[i] }
+func (n naturalStrings) Less(i, j int) bool { return NaturalLess(n[i], n[j]) }
+
+// byMessage sorts errors by the message in natural order
+type byMessage []error
+
+func (b byMessage) Len() int           { return len(b) }
+func (b byMessage) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byMessage) Less(i, j int) bool { return NaturalLess(b[i].Error(), b[j].Error()) }
+
+// NaturalLess compares strings treating runs of digits as numbers (case2 < case10)
+func NaturalLess(a, b string) bool {
+	ra, rb := []rune(a), []rune(b)
+	i, j := 0, 0
+	for i < len(ra) && j < len(rb) {
+		if unicode.IsDigit(ra[i]) && unicode.IsDigit(rb[j]) {
+			si, sj := i, j
+			for i < len(ra) && unicode.IsDigit(ra[i]) {
+				i++
+			}
+			for j < len(rb) && unicode.IsDigit(rb[j]) {
+				j++
+			}
+
+			na := strings.TrimLeft(string(ra[si:i]), "0")
+			nb := strings.TrimLeft(string(rb[sj:j]), "0")
+			if len(na) != len(nb) {
+				return len(na) < len(nb)
+			}
+			if na != nb {
+				return na < nb
+			}
+			if i-si != j-sj {
+				return i-si < j-sj
+			}
+			continue
+		}
+
+		if ra[i] != rb[j] {
+			return ra[i] < rb[j]
+		}
+		i++
+		j++
+	}
+	return len(ra)-i < len(rb)-j
+}
@@ -0,0 +1,91 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestNaturalLess(t *testing.T) {
+	testCases := []struct {
+		a, b   string
+		result bool
+	}{
+		{"case2", "case10", true},
+		{"case10", "case2", false},
+		{"a", "b", true},
+		{"01", "1", false},
+		{"1", "01", true},
+		{"case", "case1", true},
+		{"case1", "case1", false},
+	}
+
+	for _, testCase := range testCases {
+		result := NaturalLess(testCase.a, testCase.b)
+		if result != testCase.result {
+			t.Errorf("NaturalLess(%q, %q) = %v; want %v", testCase.a, testCase.b, result, testCase.result)
+		}
+	}
+
+	names := []string{"case10", "case1", "case2", "sample"}
+	sort.Sort(naturalStrings(names))
+	want := []string{"case1", "case2", "case10", "sample"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("sorted = %v; want %v", names, want)
+	}
+}
+
+func TestTestCases(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := []string{
+		"test_in/case10.txt", "test_out/case10.txt",
+		"test_in/case2.in", "test_out/case2.out",
+		"test_in/case1", "test_out/case1",
+		"test_in/case3.in", "test_in/case3.txt", "test_out/case3.out",
+		"test_in/only_in.txt",
+		"test_out/only_out.txt",
+	}
+	for _, f := range files {
+		p := filepath.Join(dir, f)
+		if err := os.MkdirAll(filepath.Dir(p), DPerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(f), FPerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases, warnings, err := TestCases(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, tc := range cases {
+		names = append(names, tc.Name)
+		if caseName(filepath.Base(tc.In)) != caseName(filepath.Base(tc.Out)) {
+			t.Errorf("mismatched pair: %s, %s", tc.In, tc.Out)
+		}
+	}
+
+	want := []string{"case1", "case2.in", "case3.in", "case10.txt"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("cases = %v; want %v", names, want)
+	}
+	duplicate := false
+	for _, w := range warnings {
+		duplicate = duplicate || strings.Contains(w.Error(), "case3.txt")
+	}
+	if len(warnings) != 3 || !duplicate {
+		t.Errorf("warnings = %v; want 3 warnings with duplicate case3.txt", warnings)
+	}
+}
@@ -0,0 +1,129 @@
+package command
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FileDiff is the difference between downloaded files and the problem directory
+type FileDiff struct {
+	Added   []string
+	Changed []string
+	Removed []string
+}
+
+// Empty reports whether there is no difference
+func (d *FileDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+// DiffFiles compares downloaded files with the problem directory.
+// If prune is true, the files recorded in info.json which are no longer downloaded are reported as removed.
+// Files added by user (test cases, sources, notes) are never removed.
+func DiffFiles(dir string, files map[string][]byte, prune bool) (*FileDiff, error) {
+	d := &FileDiff{}
+	for name, b := range files {
+		cur, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		switch {
+		case os.IsNotExist(err):
+			d.Added = append(d.Added, name)
+		case err != nil:
+			return nil, err
+		case name == InfoFile && sameInfo(cur, b):
+		case !bytes.Equal(cur, b):
+			d.Changed = append(d.Changed, name)
+		}
+	}
+
+	if prune {
+		existing, err := downloadedFiles(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range existing {
+			if _, ok := files[name]; !ok {
+				d.Removed = append(d.Removed, name)
+			}
+		}
+	}
+
+	sort.Sort(naturalStrings(d.Added))
+	sort.Sort(naturalStrings(d.Changed))
+	sort.Sort(naturalStrings(d.Removed))
+	return d, nil
+}
+
+// downloadedFiles returns the files in dir which were written by get command.
+// They are recorded in info.json; nothing is returned for info.json written without Files.
+func downloadedFiles(dir string) ([]string, error) {
+	if _, err := os.Stat(filepath.Join(dir, InfoFile)); os.IsNotExist(err) {
+		return nil, nil
+	}
+	i, err := ReadInfo(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, name := range i.Files {
+		// info.json may be edited by user
+		if clean, err := cleanName(name); err != nil || clean != name || !isProblemFile(name) {
+			continue
+		}
+		if _, err := os.Lstat(filepath.Join(dir, filepath.FromSlash(name))); err == nil {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// isProblemFile reports whether the slash-separated path is a test case or the judge code
+func isProblemFile(name string) bool {
+	return isCaseFile(name) || !strings.Contains(name, "/") && strings.HasPrefix(name, ReactiveCode+".")
+}
+
+// update merges downloaded files into the existing problem directory and reports the difference
+func (c *GetCommand) update(dir string, files map[string][]byte, prune bool) error {
+	d, err := DiffFiles(dir, files, prune)
+	if err != nil {
+		return err
+	}
+
+	changed := map[string][]byte{}
+	for _, name := range append(d.Added, d.Changed...) {
+		changed[name] = files[name]
+	}
+	if err := writeFiles(dir, changed); err != nil {
+		return err
+	}
+
+	for _, name := range d.Removed {
+		if err := os.Remove(filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			return err
+		}
+	}
+
+	if d.Empty() {
+		c.UI.Output(c.msg("get.upToDate", dir))
+		return nil
+	}
+
+	var lines []string
+	for _, name := range d.Added {
+		lines = append(lines, c.msg("get.added", path.Join(dir, name)))
+	}
+	for _, name := range d.Changed {
+		lines = append(lines, c.msg("get.changed", path.Join(dir, name)))
+	}
+	for _, name := range d.Removed {
+		lines = append(lines, c.msg("get.removed", path.Join(dir, name)))
+	}
+	lines = append(lines, c.msg("get.updated", dir, len(d.Added), len(d.Changed), len(d.Removed)))
+	c.UI.Output(strings.Join(lines, "\n"))
+	return nil
+}
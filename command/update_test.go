@@ -0,0 +1,142 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestGetCommandUpdate(t *testing.T) {
+	dir, clearDirs, err := tmpUserDirs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clearDirs()
+	restore, err := tmpChdir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restore()
+	defer setEnv("GOYUKI", "valid")()
+	defer setEnv("GOYUKI_TOKEN", "")()
+
+	api := newAPITestServer("")
+	defer api.Close()
+	get := func(cases map[string]string, args ...string) string {
+		site := newSiteTestServer(cases)
+		defer site.Close()
+
+		ui := cli.NewMockUi()
+		c := &GetCommand{Meta: Meta{UI: ui}, API: &APIClient{URL: api.URL}, URL: site.URL}
+		if code := c.Run(args); code != ExitCodeOK {
+			t.Fatalf("Run(%v) = %d; want %d\n%s", args, code, ExitCodeOK, ui.ErrorWriter.String())
+		}
+		return ui.OutputWriter.String()
+	}
+
+	get(map[string]string{"test_in/1.txt": "1", "test_out/1.txt": "1", "test_in/2.txt": "2", "test_out/2.txt": "2"}, "1")
+	user := map[string][]byte{"test_in/edge.txt": []byte("0"), "test_out/edge.txt": []byte("0")}
+	if err := writeFiles("1", user); err != nil {
+		t.Fatal(err)
+	}
+
+	out := get(map[string]string{"test_in/1.txt": "1", "test_out/1.txt": "one"}, "-update", "1")
+	for _, line := range []string{"変更\t1/test_out/1.txt", "削除\t1/test_in/2.txt", "削除\t1/test_out/2.txt"} {
+		if !strings.Contains(out, line) {
+			t.Errorf("update output = %s; want %q", out, line)
+		}
+	}
+	if strings.Contains(out, "edge.txt") {
+		t.Errorf("update output = %s; want edge.txt untouched", out)
+	}
+
+	for name, want := range map[string]string{"test_out/1.txt": "one", "test_in/edge.txt": "0", "test_out/edge.txt": "0"} {
+		if b, err := ioutil.ReadFile(filepath.Join("1", name)); err != nil || string(b) != want {
+			t.Errorf("%s = %q, %v; want %q", name, b, err, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join("1", "test_in", "2.txt")); !os.IsNotExist(err) {
+		t.Errorf("test_in/2.txt exists after update")
+	}
+}
+
+func TestDiffFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	existing := map[string][]byte{
+		"info.json":             []byte(`{"Files":["test_in/1.txt","test_in/2.txt","test_out/1.txt","test_out/2.txt","../main.cpp"]}`),
+		"test_in/edge.txt":      []byte("e"),
+		"test_out/edge.txt":     []byte("e"),
+		"test_in/1.txt":         []byte("1"),
+		"test_out/1.txt":        []byte("1"),
+		"test_in/2.txt":         []byte("2"),
+		"test_out/2.txt":        []byte("2"),
+		"test_in/custom_1.txt":  []byte("c"),
+		"test_out/custom_1.txt": []byte("c"),
+		"main.cpp":              []byte("int main(){}"),
+	}
+	if err := writeFiles(dir, existing); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string][]byte{
+		"info.json":      []byte("{}"),
+		"test_in/1.txt":  []byte("1"),
+		"test_out/1.txt": []byte("one"),
+		"test_in/3.txt":  []byte("3"),
+		"test_out/3.txt": []byte("3"),
+	}
+
+	d, err := DiffFiles(dir, files, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &FileDiff{
+		Added:   []string{"test_in/3.txt", "test_out/3.txt"},
+		Changed: []string{"info.json", "test_out/1.txt"},
+		Removed: []string{"test_in/2.txt", "test_out/2.txt"},
+	}
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("DiffFiles() = %+v; want %+v", d, want)
+	}
+
+	d, err = DiffFiles(dir, files, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Removed) != 0 {
+		t.Errorf("DiffFiles(prune = false).Removed = %v; want none", d.Removed)
+	}
+
+	ui := new(cli.MockUi)
+	c := &GetCommand{
+		Meta: Meta{
+			UI: ui,
+		},
+	}
+	if err := c.update(dir, files, true); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"test_in/custom_1.txt", "test_in/edge.txt", "main.cpp", "test_in/3.txt"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s does not exist after update: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "test_in", "2.txt")); !os.IsNotExist(err) {
+		t.Errorf("test_in/2.txt exists after update")
+	}
+	if b, _ := ioutil.ReadFile(filepath.Join(dir, "test_out", "1.txt")); string(b) != "one" {
+		t.Errorf("test_out/1.txt = %q; want %q", b, "one")
+	}
+}
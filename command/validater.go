@@ -3,7 +3,10 @@ package command
 import (
 	"bufio"
 	"bytes"
+	"io/ioutil"
 	"math"
+	"os"
+	"path/filepath"
 	"strconv"
 )
 
@@ -37,6 +40,8 @@ func (d *DiffValidater) Validate(actual, expected []byte) bool {
 // FloatValidater compares converted to float
 type FloatValidater struct {
 	Place int
+	// Tolerance is the allowed absolute or relative error (0 requires the exact match)
+	Tolerance float64
 }
 
 // Validate compares converted to float
@@ -58,7 +63,7 @@ func (f *FloatValidater) Validate(actual, expected []byte) bool {
 			return false
 		}
 
-		if f.Round(f1) != f.Round(f2) {
+		if !f.equal(f.Round(f1), f.Round(f2)) {
 			return false
 		}
 	}
@@ -69,6 +74,14 @@ func (f *FloatValidater) Validate(actual, expected []byte) bool {
 	return false
 }
 
+func (f *FloatValidater) equal(actual, expected float64) bool {
+	if actual == expected {
+		return true
+	}
+	d := math.Abs(actual - expected)
+	return d <= f.Tolerance || d <= f.Tolerance*math.Abs(expected)
+}
+
 // Round is rounding
 func (f *FloatValidater) Round(n float64) float64 {
 	if f.Place == 0 {
@@ -78,8 +91,59 @@ func (f *FloatValidater) Round(n float64) float64 {
 	return math.Floor(n*shift+.5) / shift
 }
 
+// CheckerValidater judges the output with the checker program.
+// The checker is run with the input, expected output and actual output files
+// and the output is accepted if the checker exits with status 0.
+type CheckerValidater struct {
+	Code *Code
+	// In is the input file of the current test case
+	In string
+}
+
+// Validate runs the checker
+func (c *CheckerValidater) Validate(actual, expected []byte) bool {
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		return false
+	}
+	defer os.RemoveAll(dir)
+
+	in, err := filepath.Abs(c.In)
+	if err != nil {
+		return false
+	}
+	eFile, aFile := filepath.Join(dir, "expected"), filepath.Join(dir, "actual")
+	if err := ioutil.WriteFile(eFile, expected, FPerm); err != nil {
+		return false
+	}
+	if err := ioutil.WriteFile(aFile, actual, FPerm); err != nil {
+		return false
+	}
+
+	_, err = c.Code.Output(nil, nil, in, eFile, aFile)
+	return err == nil
+}
+
 // Validaters is map of available validater
 var Validaters = map[string]Validater{
 	"diff":  &DiffValidater{},
 	"float": &FloatValidater{},
 }
+
+// NewValidater returns the validater of the name.
+// place is used by the float validater only.
+func NewValidater(name string, place int) (Validater, error) {
+	if place < 0 || place > 15 {
+		return nil, newMsgError("error.invalidRound", place)
+	}
+
+	if name == "float" {
+		return &FloatValidater{Place: place}, nil
+	}
+
+	v, ok := Validaters[name]
+	if !ok {
+		return nil, newMsgError("error.invalidValidater", name)
+	}
+	return v, nil
+}
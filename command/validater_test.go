@@ -1,6 +1,11 @@
 package command
 
-import "testing"
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 func TestDiffValidater(t *testing.T) {
 	testCases := []struct {
@@ -67,3 +72,63 @@ func TestRoundFloatValidater(t *testing.T) {
 		}
 	}
 }
+
+func TestToleranceFloatValidater(t *testing.T) {
+	testCases := []struct {
+		b1     []byte
+		b2     []byte
+		result bool
+	}{
+		{[]byte("1.0000001"), []byte("1"), true},
+		{[]byte("1.00001"), []byte("1"), false},
+		{[]byte("1000000.1"), []byte("1000000"), true},
+		{[]byte("0.0000009"), []byte("0"), true},
+		{[]byte("1 2"), []byte("1"), false},
+	}
+
+	validater := FloatValidater{Tolerance: 1e-6}
+	for _, testCase := range testCases {
+		result := validater.Validate(testCase.b1, testCase.b2)
+		if result != testCase.result {
+			t.Errorf("Validate(%s, %s) = %v; want %v", testCase.b1, testCase.b2, result, testCase.result)
+		}
+	}
+}
+
+func TestCheckerValidater(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goyuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// accepts the output if the first characters are the same
+	checker := filepath.Join(dir, "checker.sh")
+	src := "test \"$(head -c 1 \"$2\")\" = \"$(head -c 1 \"$3\")\"\n"
+	if err := ioutil.WriteFile(checker, []byte(src), FPerm); err != nil {
+		t.Fatal(err)
+	}
+
+	code, _, clearFunc, err := NewCode(checker, Lang["sh"], &Info{Time: 1}, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clearFunc()
+
+	testCases := []struct {
+		b1     []byte
+		b2     []byte
+		result bool
+	}{
+		{[]byte("1 foo"), []byte("1 bar"), true},
+		{[]byte("2"), []byte("1"), false},
+	}
+
+	validater := CheckerValidater{Code: code, In: "testdata/337/info.json"}
+	for _, testCase := range testCases {
+		result := validater.Validate(testCase.b1, testCase.b2)
+		if result != testCase.result {
+			t.Errorf("Validate(%s, %s) = %v; want %v", testCase.b1, testCase.b2, result, testCase.result)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// WhoamiCommand is a Command that shows the credentials in use
+type WhoamiCommand struct {
+	Meta
+
+	// API is used instead of yukicoder API if it is not nil
+	API *APIClient
+
+	// URL is used instead of BaseURL if it is not empty
+	URL string
+}
+
+// Run shows where the credentials come from and whether they are valid
+func (c *WhoamiCommand) Run(args []string) int {
+	flags := c.Meta.NewFlagSet("whoami", c.Help())
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(c.msg("error.invalidOption", strings.Join(args, " ")))
+		return ExitCodeFailed
+	}
+
+	cred, err := LoadCredential()
+	if err != nil {
+		c.UI.Error(c.errMsg(err))
+		return ExitCodeFailed
+	}
+
+	if cred.Cookie == "" && cred.Token == "" {
+		c.UI.Error(c.msg("whoami.notLoggedIn"))
+		return ExitCodeFailed
+	}
+
+	l := &LoginCommand{API: c.API, URL: c.URL}
+	code := ExitCodeOK
+	status := func(env, value string, check func() error) string {
+		if value == "" {
+			return c.msg("whoami.unset")
+		}
+
+		from := CredentialFile
+		if os.Getenv(env) != "" {
+			from = "$" + env
+		}
+		if err := check(); err != nil {
+			code = ExitCodeFailed
+			return fmt.Sprintf("%s\t%s (%v)", from, c.msg("whoami.invalid"), err)
+		}
+		return fmt.Sprintf("%s\t%s", from, c.msg("whoami.valid"))
+	}
+
+	strs := make([]string, 2)
+	strs[0] = "Cookie:\t\t" + status("GOYUKI", cred.Cookie, func() error {
+		return checkCookie(l.baseURL(), cred.Cookie)
+	})
+	strs[1] = "Token:\t\t" + status("GOYUKI_TOKEN", cred.Token, func() error {
+		return checkToken(l.api(cred.Token))
+	})
+	c.UI.Output(strings.Join(strs, "\n"))
+	return code
+}
+
+// Synopsis is a one-line, short synopsis of the command.
+func (c *WhoamiCommand) Synopsis() string {
+	return c.msg("whoami.synopsis")
+}
+
+// Help is a long-form help text
+func (c *WhoamiCommand) Help() string {
+	return strings.TrimSpace(c.msg("whoami.help"))
+}
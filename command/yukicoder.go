@@ -0,0 +1,459 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/mitchellh/cli"
+)
+
+// Yukicoder is the Provider of yukicoder.
+// Test cases are downloaded from API with the token, as zip with the cookie,
+// or from the problem statement if Samples is true.
+type Yukicoder struct {
+	UI      cli.Ui
+	Cookie  string
+	API     *APIClient
+	Samples bool
+
+	// HTTP sends the requests to the site (DefaultHTTPClient if nil)
+	HTTP *HTTPClient
+
+	// Base is used instead of BaseURL if it is not empty
+	Base string
+
+	// Locale is the locale of the warnings (DetectLocale if empty)
+	Locale string
+
+	mu    sync.Mutex
+	pages map[string][]byte
+	codes map[string][]byte
+}
+
+var (
+	yukicoderNoURL = regexp.MustCompile(`^(?:https?://)?yukicoder\.me/problems/no/(\d+)/?$`)
+	yukicoderIDURL = regexp.MustCompile(`^(?:https?://)?yukicoder\.me/problems/(\d+)/?$`)
+)
+
+// Name returns the site name
+func (y *Yukicoder) Name() string {
+	return "yukicoder"
+}
+
+// Parse accepts a problem number, a problem url (/problems/no/N)
+// or a problem id url (/problems/ID).
+// The problem id is resolved to the problem number with API.
+func (y *Yukicoder) Parse(spec string) (string, bool, error) {
+	if _, err := strconv.Atoi(spec); err == nil {
+		return spec, true, nil
+	}
+
+	if m := yukicoderNoURL.FindStringSubmatch(spec); m != nil {
+		return m[1], true, nil
+	}
+
+	if m := yukicoderIDURL.FindStringSubmatch(spec); m != nil {
+		id, err := strconv.Atoi(m[1])
+		if err != nil {
+			return "", false, err
+		}
+
+		p, err := y.API.ProblemByID(id)
+		if err != nil {
+			return "", false, newMsgError("error.problemID", id, err)
+		}
+		return fmt.Sprint(p.No), true, nil
+	}
+	return "", false, nil
+}
+
+// Info gets the problem infomation and the judge code if logged in
+func (y *Yukicoder) Info(id string) (*Info, error) {
+	num, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+
+	page, perr := y.downloadProblem(num)
+	i, err := y.info(num, page, perr)
+	if err != nil {
+		return nil, err
+	}
+	i.Number = num
+
+	var code []byte
+	if y.Cookie != "" {
+		code, err = y.downloadReactive(i)
+		if err != nil {
+			return nil, err
+		}
+	} else if i.JudgeType != Normal {
+		y.UI.Warn(y.msg("yukicoder.judgeCode"))
+		i.JudgeType = Normal
+	}
+
+	y.mu.Lock()
+	defer y.mu.Unlock()
+	if y.pages == nil {
+		y.pages, y.codes = map[string][]byte{}, map[string][]byte{}
+	}
+	y.pages[id], y.codes[id] = page, code
+	return i, nil
+}
+
+// TestCases gets the test cases of the problem
+func (y *Yukicoder) TestCases(id string, i *Info) (map[string][]byte, error) {
+	switch {
+	case y.Samples:
+		y.mu.Lock()
+		page := y.pages[id]
+		y.mu.Unlock()
+
+		samples, err := parseSamples(bytes.NewReader(page))
+		if err != nil {
+			return nil, err
+		}
+		return sampleFiles(samples), nil
+	case y.API.Token != "":
+		num, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, err
+		}
+		return y.API.TestCases(num)
+	}
+
+	b, err := y.downloadTestCase(i)
+	if err != nil {
+		return nil, err
+	}
+	return unzipFiles(b)
+}
+
+// JudgeCode returns the judge code downloaded by Info
+func (y *Yukicoder) JudgeCode(id string, i *Info) ([]byte, error) {
+	y.mu.Lock()
+	defer y.mu.Unlock()
+	return y.codes[id], nil
+}
+
+// Statement returns the problem page downloaded by Info
+func (y *Yukicoder) Statement(id string) []byte {
+	y.mu.Lock()
+	defer y.mu.Unlock()
+	return y.pages[id]
+}
+
+// Forget drops the problem page and the judge code downloaded by Info
+func (y *Yukicoder) Forget(id string) {
+	y.mu.Lock()
+	defer y.mu.Unlock()
+	delete(y.pages, id)
+	delete(y.codes, id)
+}
+
+// msg returns the message of the key in Locale
+func (y *Yukicoder) msg(key string, args ...interface{}) string {
+	locale := y.Locale
+	if locale == "" {
+		locale = DetectLocale()
+	}
+	return Message(locale, key, args...)
+}
+
+// URL returns the problem page url
+func (y *Yukicoder) URL(id string) string {
+	return strings.Join([]string{y.baseURL(), "no", id}, "/")
+}
+
+// SamplesOnly reports whether TestCases returns the sample cases only
+func (y *Yukicoder) SamplesOnly() bool {
+	return y.Samples
+}
+
+// Submit submits the source with API and returns the submission id.
+// lang is the key of Lang.
+func (y *Yukicoder) Submit(id, lang string, source []byte) (string, error) {
+	num, err := strconv.Atoi(id)
+	if err != nil {
+		return "", err
+	}
+
+	l, ok := SubmitLang[lang]
+	if !ok {
+		return "", newMsgError("error.invalidLanguage", lang)
+	}
+
+	sid, err := y.API.Submit(num, l, source)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprint(sid), nil
+}
+
+// info gets the problem infomation from API and fills the fields API doesn't provide from the problem page.
+// perr is the error of downloading the page.
+// If API is unavailable, all infomation comes from the problem page.
+// If the page is unavailable, the default limits are used.
+func (y *Yukicoder) info(num int, page []byte, perr error) (*Info, error) {
+	p, err := y.API.Problem(num)
+	if err != nil {
+		if perr != nil {
+			return nil, perr
+		}
+		y.UI.Warn(y.msg("yukicoder.pageFallback", err))
+		return parse(bytes.NewReader(page))
+	}
+
+	i := p.Info()
+	var pi *Info
+	if perr == nil {
+		pi, perr = parse(bytes.NewReader(page))
+	}
+	if perr != nil {
+		y.UI.Warn(y.msg("yukicoder.defaultLimit", perr))
+		i.Time, i.Mem = DefaultTime, DefaultMem
+		return i, nil
+	}
+
+	fillInfo(i, pi)
+	return i, nil
+}
+
+// fillInfo copies the fields of src to the empty fields of dst
+func fillInfo(dst, src *Info) {
+	if dst.No == "" {
+		dst.No = src.No
+	}
+	if dst.Name == "" {
+		dst.Name = src.Name
+	}
+	if dst.Level == 0 {
+		dst.Level = src.Level
+	}
+	if dst.Time == 0 {
+		dst.Time = src.Time
+	}
+	if dst.Mem == 0 {
+		dst.Mem = src.Mem
+	}
+	if dst.JudgeType == Normal {
+		dst.JudgeType = src.JudgeType
+	}
+	if dst.Author == "" {
+		dst.Author = src.Author
+	}
+	if dst.Contest == "" {
+		dst.Contest = src.Contest
+	}
+}
+
+// client returns the client of the requests
+func (y *Yukicoder) client() *HTTPClient {
+	if y.HTTP == nil {
+		return DefaultHTTPClient
+	}
+	return y.HTTP
+}
+
+func (y *Yukicoder) baseURL() string {
+	if y.Base != "" {
+		return y.Base
+	}
+	return BaseURL
+}
+
+// sessionRequest returns the request with the REVEL_SESSION cookie if it is not empty
+func sessionRequest(method, uri, cookie string) (*http.Request, error) {
+	req, err := http.NewRequest(method, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if cookie != "" {
+		req.AddCookie(&http.Cookie{
+			Name:     "REVEL_SESSION",
+			Value:    cookie,
+			Path:     "/",
+			HttpOnly: true,
+		})
+	}
+	return req, nil
+}
+
+func (y *Yukicoder) downloadProblem(num int) ([]byte, error) {
+	uri := strings.Join([]string{y.baseURL(), "no", fmt.Sprint(num)}, "/")
+
+	res, err := y.client().Get(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed problem request: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, newMsgError("error.problemNotFound")
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed problem request: %s", res.Status)
+	}
+
+	return ioutil.ReadAll(res.Body)
+}
+
+func (y *Yukicoder) downloadTestCase(i *Info) ([]byte, error) {
+	testCaseURI := strings.Join([]string{y.baseURL(), i.No, "testcase.zip"}, "/")
+	req, err := sessionRequest("GET", testCaseURI, y.Cookie)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := y.client().Do(WithMaxRedirects(req, 0))
+	if err != nil {
+		return nil, fmt.Errorf("failed testcase request: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusForbidden {
+		return nil, newMsgError("error.loginRequired")
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed testcase request: %s", res.Status)
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, 1000000))
+	if _, err := io.Copy(buf, res.Body); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func parse(r io.Reader) (*Info, error) {
+	i := &Info{}
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("problem parse error: %v", err)
+	}
+
+	content := doc.Find("div#content")
+	p := content.Find("p")
+
+	i.No, _ = content.Attr("data-problem-id")
+	i.Name = content.Find("h3").Text()
+	i.Level = p.First().Find("i.fa-star").Size()
+	i.Author = strings.TrimSpace(content.Find("a[href*='/users/']").First().Text())
+	i.Contest = strings.TrimSpace(content.Find("a[href*='/contests/']").First().Text())
+
+	// the judge type is shown next to the limits
+	infoData := p.First().Text()
+	switch {
+	case strings.Contains(infoData, "スペシャルジャッジ"):
+		i.JudgeType = Special
+	case strings.Contains(infoData, "リアクティブ"):
+		i.JudgeType = Reactive
+	}
+
+	reg, _ := regexp.Compile(`[\d]+`)
+	match := reg.FindAllStringSubmatch(infoData, -1)
+	if len(match) < 3 {
+		return nil, fmt.Errorf("problem parse error: time and memory limit not found")
+	}
+
+	tm := [2]int{}
+	for i, v := range match[1:3] {
+		n, err := strconv.Atoi(v[0])
+		if err != nil {
+			return nil, err
+		}
+		tm[i] = n
+	}
+	i.Time, i.Mem = tm[0], tm[1]
+
+	return i, nil
+}
+
+func parseSamples(r io.Reader) ([]*Sample, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("sample parse error: %v", err)
+	}
+
+	var samples []*Sample
+	doc.Find("div#content div.sample").Each(func(n int, e *goquery.Selection) {
+		pre := e.Find("pre")
+		if pre.Size() < 2 {
+			return
+		}
+		samples = append(samples, &Sample{
+			In:  sampleText(pre.Eq(0).Text()),
+			Out: sampleText(pre.Eq(1).Text()),
+		})
+	})
+
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("sample cases not found")
+	}
+	return samples, nil
+}
+
+func (y *Yukicoder) downloadReactive(i *Info) ([]byte, error) {
+	uri := strings.Join([]string{y.baseURL(), i.No, "code"}, "/")
+	req, err := sessionRequest("GET", uri, y.Cookie)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := y.client().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed problem request: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed judge code request: %s", res.Status)
+	}
+
+	buf, err := parseReactive(res.Body, i)
+	if err != nil {
+		return nil, fmt.Errorf("reactive code parse error: %v", err)
+	}
+	return buf, nil
+}
+
+func parseReactive(r io.Reader, i *Info) ([]byte, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("judge code parse error: %v", err)
+	}
+
+	isReactive := false
+	doc.Find("option").EachWithBreak(func(n int, e *goquery.Selection) bool {
+		if _, ok := e.Attr("selected"); !ok {
+			return true
+		}
+
+		lang := e.Text()
+		i.RLang, isReactive = lang[:strings.Index(lang, " ")], true
+		return false
+	})
+
+	if isReactive {
+		if i.JudgeType != Special {
+			i.JudgeType = Reactive
+		}
+	} else {
+		i.JudgeType = Normal
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, 1000000))
+	if _, err := buf.WriteString(doc.Find("textarea").Text()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -7,16 +7,71 @@ import (
 
 func Commands(meta *command.Meta) map[string]cli.CommandFactory {
 	return map[string]cli.CommandFactory{
+		"add": func() (cli.Command, error) {
+			return &command.AddCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"config": func() (cli.Command, error) {
+			return &command.ConfigCommand{
+				Meta: *meta,
+			}, nil
+		},
 		"get": func() (cli.Command, error) {
 			return &command.GetCommand{
 				Meta: *meta,
 			}, nil
 		},
+		"history": func() (cli.Command, error) {
+			return &command.HistoryCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"login": func() (cli.Command, error) {
+			return &command.LoginCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"logout": func() (cli.Command, error) {
+			return &command.LogoutCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"mirror": func() (cli.Command, error) {
+			return &command.MirrorCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"new": func() (cli.Command, error) {
+			return &command.NewCommand{
+				Meta: *meta,
+			}, nil
+		},
 		"run": func() (cli.Command, error) {
 			return &command.RunCommand{
 				Meta: *meta,
 			}, nil
 		},
+		"show": func() (cli.Command, error) {
+			return &command.ShowCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"stress": func() (cli.Command, error) {
+			return &command.StressCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"submit": func() (cli.Command, error) {
+			return &command.SubmitCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"whoami": func() (cli.Command, error) {
+			return &command.WhoamiCommand{
+				Meta: *meta,
+			}, nil
+		},
 
 		"version": func() (cli.Command, error) {
 			return &command.VersionCommand{